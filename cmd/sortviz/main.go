@@ -0,0 +1,162 @@
+// Sortviz visualizes the operations performed by the sorting algorithms of the sort package.
+//
+// It traces the sort of a generated input using [sort.Trace] and renders the trace
+// as an animated SVG image or as ASCII bars redrawn in the terminal.
+//
+// Usage:
+//
+//	sortviz [flags]
+//
+// The flags are:
+//
+//	-alg name
+//		the algorithm to trace: Insertion, Selection, Merge, MergeBottomUp, Heap or Shell (default Insertion)
+//	-pattern name
+//		the input pattern: random, sorted, reversed or few-unique (default random)
+//	-n length
+//		the length of the input (default 32)
+//	-seed seed
+//		the seed of the random input patterns (default 1)
+//	-format format
+//		the output format: ascii or svg (default ascii)
+//	-delay duration
+//		the duration of a single operation (default 50ms)
+//	-height rows
+//		the height of the ASCII bars in rows (default 16)
+//	-o file
+//		the output file (default standard output)
+//
+// For example, to render the merge sort of a reversed slice as an SVG image:
+//
+//	sortviz -alg Merge -pattern reversed -format svg -o merge.svg
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+func main() {
+	var (
+		algName = flag.String("alg", "Insertion", "the algorithm to trace")
+		pattern = flag.String("pattern", "random", "the input pattern: random, sorted, reversed or few-unique")
+		n       = flag.Int("n", 32, "the length of the input")
+		seed    = flag.Int64("seed", 1, "the seed of the random input patterns")
+		format  = flag.String("format", "ascii", "the output format: ascii or svg")
+		delay   = flag.Duration("delay", 50*time.Millisecond, "the duration of a single operation")
+		height  = flag.Int("height", 16, "the height of the ASCII bars in rows")
+		output  = flag.String("o", "", "the output file (default standard output)")
+	)
+	flag.Parse()
+
+	if err := run(*algName, *pattern, *n, *seed, *format, *delay, *height, *output); err != nil {
+		fmt.Fprintln(os.Stderr, "sortviz:", err)
+		os.Exit(1)
+	}
+}
+
+func run(algName, pattern string, n int, seed int64, format string, delay time.Duration, height int, output string) error {
+	alg, err := parseAlgorithm(algName)
+	if err != nil {
+		return err
+	}
+	if n <= 0 {
+		return fmt.Errorf("invalid length %d", n)
+	}
+	data, err := generate(pattern, n, rand.New(rand.NewSource(seed)))
+	if err != nil {
+		return err
+	}
+
+	var render func(w io.Writer, data []int, events []sort.Event[int]) error
+	switch format {
+	case "ascii":
+		if height <= 0 {
+			return fmt.Errorf("invalid height %d", height)
+		}
+		render = func(w io.Writer, data []int, events []sort.Event[int]) error {
+			return renderASCII(w, data, events, height, delay)
+		}
+	case "svg":
+		if delay <= 0 {
+			return fmt.Errorf("invalid delay %v", delay)
+		}
+		render = func(w io.Writer, data []int, events []sort.Event[int]) error {
+			return renderSVG(w, data, events, delay)
+		}
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	var events []sort.Event[int]
+	sort.Trace(alg, append([]int(nil), data...), cmp.Compare[int], func(e sort.Event[int]) {
+		events = append(events, e)
+	})
+
+	w := os.Stdout
+	if output != "" {
+		if w, err = os.Create(output); err != nil {
+			return err
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	err = render(bw, data, events)
+	if ferr := bw.Flush(); err == nil {
+		err = ferr
+	}
+	if w != os.Stdout {
+		if cerr := w.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// parseAlgorithm returns the traceable algorithm with the name, ignoring case.
+func parseAlgorithm(name string) (sort.Algorithm, error) {
+	var names []string
+	for _, alg := range sort.Algorithms {
+		if !alg.Traceable() {
+			continue
+		}
+		if strings.EqualFold(alg.String(), name) {
+			return alg, nil
+		}
+		names = append(names, alg.String())
+	}
+	return 0, fmt.Errorf("unknown algorithm %q, must be one of %s", name, strings.Join(names, ", "))
+}
+
+// generate returns an input of length n of the pattern.
+func generate(pattern string, n int, r *rand.Rand) ([]int, error) {
+	data := make([]int, n)
+	switch pattern {
+	case "random":
+		data = r.Perm(n)
+	case "sorted":
+		for i := range data {
+			data[i] = i
+		}
+	case "reversed":
+		for i := range data {
+			data[i] = n - 1 - i
+		}
+	case "few-unique":
+		for i := range data {
+			data[i] = r.Intn(4) * n / 4
+		}
+	default:
+		return nil, fmt.Errorf("unknown pattern %q", pattern)
+	}
+	return data, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+func TestParseAlgorithm(t *testing.T) {
+	for _, name := range []string{"Insertion", "merge", "MERGEBOTTOMUP", "Shell"} {
+		alg, err := parseAlgorithm(name)
+		if err != nil {
+			t.Fatalf("parseAlgorithm(%q) error: %v", name, err)
+		}
+		if !alg.Traceable() {
+			t.Errorf("parseAlgorithm(%q) = %v; want a traceable algorithm", name, alg)
+		}
+	}
+	for _, name := range []string{"", "Pdq", "bogo"} {
+		if _, err := parseAlgorithm(name); err == nil {
+			t.Errorf("parseAlgorithm(%q) didn't fail", name)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	n := 50
+	r := rand.New(rand.NewSource(1))
+
+	tests := []struct {
+		pattern string
+		check   func(s []int) bool
+	}{
+		{"random", func(s []int) bool {
+			slices.Sort(s)
+			for i, v := range s {
+				if v != i {
+					return false
+				}
+			}
+			return true
+		}},
+		{"sorted", func(s []int) bool { return slices.IsSorted(s) }},
+		{"reversed", func(s []int) bool { slices.Reverse(s); return slices.IsSorted(s) && s[0] == 0 }},
+		{"few-unique", func(s []int) bool { slices.Sort(s); return len(slices.Compact(s)) <= 4 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			s, err := generate(tt.pattern, n, r)
+			if err != nil {
+				t.Fatalf("generate error: %v", err)
+			}
+			if len(s) != n || !tt.check(s) {
+				t.Errorf("generate(%q) = %v", tt.pattern, s)
+			}
+		})
+	}
+
+	if _, err := generate("zigzag", n, r); err == nil {
+		t.Errorf("generate didn't fail on an unknown pattern")
+	}
+}
+
+func trace(alg sort.Algorithm, data []int) []sort.Event[int] {
+	var events []sort.Event[int]
+	sort.Trace(alg, slices.Clone(data), func(a, b int) int { return a - b }, func(e sort.Event[int]) {
+		events = append(events, e)
+	})
+	return events
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+// Bar characters and colors of the elements by the last operation performed on them.
+const (
+	asciiBar     = '#'
+	asciiCompare = '?'
+	asciiChange  = '*'
+
+	svgBar     = "#4a7ab5"
+	svgCompare = "#f0b429"
+	svgChange  = "#d64545"
+)
+
+const (
+	svgBarWidth = 12
+	svgHeight   = 240
+)
+
+// renderASCII writes the frames of the animation of the events as ASCII bars,
+// clearing the terminal before each frame and waiting delay after it.
+func renderASCII(w io.Writer, data []int, events []sort.Event[int], height int, delay time.Duration) error {
+	s := slices.Clone(data)
+	hi := slices.Max(s)
+
+	var b strings.Builder
+	for k := 0; k <= len(events); k++ {
+		var e sort.Event[int]
+		if k > 0 {
+			e = events[k-1]
+			sort.Replay(s, events[k-1:k])
+		}
+
+		b.Reset()
+		b.WriteString("\x1b[H\x1b[2J")
+		for row := height; row > 0; row-- {
+			for i, v := range s {
+				c := byte(' ')
+				if barHeight(v, hi, height) >= row {
+					c = asciiBar
+					if k > 0 && touches(e, i) {
+						c = asciiCompare
+						if e.Op != sort.OpCompare {
+							c = asciiChange
+						}
+					}
+				}
+				b.WriteByte(c)
+			}
+			b.WriteByte('\n')
+		}
+		if k > 0 {
+			fmt.Fprintf(&b, "%d/%d %s\n", k, len(events), describe(e))
+		} else {
+			fmt.Fprintf(&b, "0/%d\n", len(events))
+		}
+
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+		if f, ok := w.(interface{ Flush() error }); ok {
+			if err := f.Flush(); err != nil {
+				return err
+			}
+		}
+		time.Sleep(delay)
+	}
+	return nil
+}
+
+// renderSVG writes an SVG image animating the events, each lasting delay.
+// Each bar is animated independently using discrete SMIL animations of its height and color.
+func renderSVG(w io.Writer, data []int, events []sort.Event[int], delay time.Duration) error {
+	s := slices.Clone(data)
+	hi := slices.Max(s)
+	n := len(s)
+
+	// The keyframes of each bar, at the frames where its height or color changes.
+	type keyframe struct {
+		frame, height int
+		color         string
+	}
+	keyframes := make([][]keyframe, n)
+	for i, v := range s {
+		keyframes[i] = []keyframe{{0, barHeight(v, hi, svgHeight), svgBar}}
+	}
+	set := func(i, frame int, color string) {
+		kf := keyframe{frame, barHeight(s[i], hi, svgHeight), color}
+		kfs := keyframes[i]
+		if last := kfs[len(kfs)-1]; last.frame == frame && len(kfs) > 1 {
+			kfs = kfs[:len(kfs)-1] // overwritten by the operation at the same frame
+		}
+		if last := kfs[len(kfs)-1]; last.height != kf.height || last.color != kf.color {
+			kfs = append(kfs, kf)
+		}
+		keyframes[i] = kfs
+	}
+
+	var prev sort.Event[int]
+	for k, e := range events {
+		frame := k + 1
+		sort.Replay(s, events[k:k+1])
+
+		// Reset the color of the elements of the previous operation.
+		if k > 0 {
+			for _, i := range indexes(prev) {
+				set(i, frame, svgBar)
+			}
+		}
+		color := svgChange
+		if e.Op == sort.OpCompare {
+			color = svgCompare
+		}
+		for _, i := range indexes(e) {
+			set(i, frame, color)
+		}
+		prev = e
+	}
+	frames := len(events) + 1
+	dur := strconv.FormatFloat((time.Duration(frames) * delay).Seconds(), 'f', -1, 64)
+
+	width := n * svgBarWidth
+	if _, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, svgHeight, width, svgHeight); err != nil {
+		return err
+	}
+	for i, kfs := range keyframes {
+		x := i * svgBarWidth
+		fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s">`+"\n", x, svgHeight-kfs[0].height, svgBarWidth-1, kfs[0].height, kfs[0].color)
+		if len(kfs) > 1 {
+			var keyTimes, ys, heights, colors []string
+			for _, kf := range kfs {
+				keyTimes = append(keyTimes, strconv.FormatFloat(float64(kf.frame)/float64(frames), 'f', -1, 64))
+				ys = append(ys, strconv.Itoa(svgHeight-kf.height))
+				heights = append(heights, strconv.Itoa(kf.height))
+				colors = append(colors, kf.color)
+			}
+			for _, a := range []struct {
+				name   string
+				values []string
+			}{{"y", ys}, {"height", heights}, {"fill", colors}} {
+				fmt.Fprintf(w, `<animate attributeName="%s" values="%s" keyTimes="%s" dur="%ss" calcMode="discrete" fill="freeze"/>`+"\n",
+					a.name, strings.Join(a.values, ";"), strings.Join(keyTimes, ";"), dur)
+			}
+		}
+		fmt.Fprintln(w, "</rect>")
+	}
+	_, err := fmt.Fprintln(w, "</svg>")
+	return err
+}
+
+// barHeight returns the height of the bar of the value v scaled to the maximum height h,
+// where hi is the maximum value. Bars are at least 1 high.
+func barHeight(v, hi, h int) int {
+	return max(1, (v+1)*h/(hi+1))
+}
+
+// indexes returns the indexes of the elements affected by the event.
+func indexes(e sort.Event[int]) []int {
+	if e.Op == sort.OpWrite {
+		return []int{e.I}
+	}
+	return []int{e.I, e.J}
+}
+
+// touches reports whether the event affects the element at index i.
+func touches(e sort.Event[int], i int) bool {
+	return slices.Contains(indexes(e), i)
+}
+
+// describe returns a human-readable description of the event.
+func describe(e sort.Event[int]) string {
+	if e.Op == sort.OpWrite {
+		return fmt.Sprintf("%v(%d) = %d", e.Op, e.I, e.Value)
+	}
+	return fmt.Sprintf("%v(%d, %d)", e.Op, e.I, e.J)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/xml"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+func TestRenderASCII(t *testing.T) {
+	data := []int{3, 0, 2, 1}
+	events := trace(sort.InsertionSort, data)
+
+	var b strings.Builder
+	if err := renderASCII(&b, data, events, 4, 0); err != nil {
+		t.Fatalf("renderASCII error: %v", err)
+	}
+
+	frames := strings.Split(b.String(), "\x1b[H\x1b[2J")[1:]
+	if len(frames) != len(events)+1 {
+		t.Fatalf("rendered %d frames; want %d", len(frames), len(events)+1)
+	}
+	last := strings.NewReplacer(string(asciiCompare), string(asciiBar), string(asciiChange), string(asciiBar)).Replace(frames[len(frames)-1])
+	if want := "   #\n  ##\n ###\n####\n"; !strings.HasPrefix(last, want) {
+		t.Errorf("last frame = %q; want prefix %q", last, want)
+	}
+	if want := "#   \n# # \n# ##\n####\n0/"; !strings.HasPrefix(frames[0], want) {
+		t.Errorf("first frame = %q; want prefix %q", frames[0], want)
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	data := []int{5, 1, 4, 0, 3, 2}
+	for _, alg := range []sort.Algorithm{sort.SelectionSort, sort.MergeSort} {
+		t.Run(alg.String(), func(t *testing.T) {
+			var b strings.Builder
+			if err := renderSVG(&b, data, trace(alg, data), 0); err != nil {
+				t.Fatalf("renderSVG error: %v", err)
+			}
+
+			// The final height of each bar is the last value of its height animation.
+			var heights []string
+			d := xml.NewDecoder(strings.NewReader(b.String()))
+			for {
+				tok, err := d.Token()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("invalid SVG: %v", err)
+				}
+				se, ok := tok.(xml.StartElement)
+				if !ok {
+					continue
+				}
+				for _, a := range se.Attr {
+					switch {
+					case se.Name.Local == "rect" && a.Name.Local == "height":
+						heights = append(heights, a.Value)
+					case se.Name.Local == "animate" && a.Name.Local == "values":
+						if attr(se, "attributeName") == "height" {
+							vs := strings.Split(a.Value, ";")
+							heights[len(heights)-1] = vs[len(vs)-1]
+						}
+					}
+				}
+			}
+
+			if len(heights) != len(data) {
+				t.Fatalf("rendered %d bars; want %d", len(heights), len(data))
+			}
+			if want := []string{"40", "80", "120", "160", "200", "240"}; !slices.Equal(heights, want) {
+				t.Errorf("final bar heights = %v; want %v", heights, want)
+			}
+		})
+	}
+}
+
+func attr(se xml.StartElement, name string) string {
+	for _, a := range se.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
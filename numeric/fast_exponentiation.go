@@ -1,6 +1,9 @@
 package numeric
 
-import "math/big"
+import (
+	"math/big"
+	"math/bits"
+)
 
 // FastExp computes a^n using exponentiation by squaring (fast exponentiation).
 // a and n are non-negative integers.
@@ -18,3 +21,27 @@ func FastExp(a, n uint64) *big.Int {
 		return x.Mul(new(big.Int).SetUint64(a), x)
 	}
 }
+
+// FastExpMod computes a^n mod m using exponentiation by squaring (fast exponentiation).
+// a and n are non-negative integers, and m is a positive integer.
+// Unlike FastExp, it doesn't allocate, since the intermediate products are reduced modulo m using 128-bit arithmetic.
+func FastExpMod(a, n, m uint64) uint64 {
+	if n == 0 {
+		return 1 % m
+	}
+
+	x := FastExpMod(a, n/2, m)
+	x = mulMod(x, x, m) // x^2
+
+	if n%2 == 0 {
+		return x
+	} else {
+		return mulMod(a%m, x, m)
+	}
+}
+
+// mulMod computes a*b mod m without overflow.
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
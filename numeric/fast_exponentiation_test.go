@@ -39,3 +39,31 @@ func TestFastExp(t *testing.T) {
 		})
 	}
 }
+
+func TestFastExpMod(t *testing.T) {
+	tests := []struct {
+		a, n, m uint64
+	}{
+		{0, 0, 1},
+		{0, 0, 7},
+		{5, 0, 7},
+		{0, 10, 7},
+		{2, 10, 1000},
+		{3, 5, 7},
+		{123, 14, 1<<61 - 1},
+		{11, 101, 1<<64 - 1},
+		{1<<64 - 1, 1<<64 - 1, 1<<64 - 59},
+		{1<<61 - 2, 12345678901234, 1<<61 - 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d^%d mod %d", tt.a, tt.n, tt.m), func(t *testing.T) {
+			got := numeric.FastExpMod(tt.a, tt.n, tt.m)
+			want := new(big.Int).Exp(new(big.Int).SetUint64(tt.a), new(big.Int).SetUint64(tt.n), new(big.Int).SetUint64(tt.m))
+
+			if want.Cmp(new(big.Int).SetUint64(got)) != 0 {
+				t.Errorf("got %d; want %v", got, want)
+			}
+		})
+	}
+}
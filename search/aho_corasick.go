@@ -0,0 +1,471 @@
+package search
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+// ahoCorasickChunk is the size of the chunks read by [AhoCorasick.FindReader].
+const ahoCorasickChunk = 32 << 10
+
+// MatchKind defines which matches are reported by [AhoCorasick].
+type MatchKind int
+
+const (
+	// OverlappingMatch reports all matches, including the overlapping ones.
+	// The matches ending at the same offset are reported from the longest one to the shortest one.
+	OverlappingMatch MatchKind = iota
+	// LeftmostLongestMatch reports non-overlapping matches: scanning the text from left to right,
+	// it reports the match that starts first, and the longest one of those starting at the same offset,
+	// then continues after its end.
+	LeftmostLongestMatch
+)
+
+var matchKindNames = [...]string{
+	OverlappingMatch:     "Overlapping",
+	LeftmostLongestMatch: "LeftmostLongest",
+}
+
+// String returns the name of the match kind.
+func (k MatchKind) String() string {
+	if k < 0 || int(k) >= len(matchKindNames) {
+		return fmt.Sprintf("MatchKind(%d)", int(k))
+	}
+	return matchKindNames[k]
+}
+
+// Representation defines how the transitions of [AhoCorasick] are stored.
+type Representation int
+
+const (
+	// DenseTable stores all 256 transitions of each state, including those following the failure links,
+	// so matching takes a single table lookup per byte, but it uses 1 KiB per state.
+	DenseTable Representation = iota
+	// DoubleArray stores only the transitions of the trie of the patterns in the double-array layout,
+	// and follows the failure links while matching, so it uses about 16 bytes per state, but it's slower.
+	DoubleArray
+)
+
+var representationNames = [...]string{
+	DenseTable:  "DenseTable",
+	DoubleArray: "DoubleArray",
+}
+
+// String returns the name of the representation.
+func (r Representation) String() string {
+	if r < 0 || int(r) >= len(representationNames) {
+		return fmt.Sprintf("Representation(%d)", int(r))
+	}
+	return representationNames[r]
+}
+
+// AhoCorasickOptions configures an [AhoCorasick] automaton.
+// The zero value is ready to use.
+type AhoCorasickOptions struct {
+	// MatchKind defines which matches are reported. The default is OverlappingMatch.
+	MatchKind MatchKind
+	// CaseInsensitive makes the matching ignore the case of ASCII letters.
+	CaseInsensitive bool
+	// Representation defines how the transitions are stored. The default is DenseTable.
+	Representation Representation
+}
+
+// Match is an occurrence of a pattern of [AhoCorasick] at text[Start:End].
+type Match struct {
+	// Pattern is the index of the pattern in the slice of patterns of the automaton.
+	Pattern    int
+	Start, End int
+}
+
+/*
+AhoCorasick implements the Aho–Corasick algorithm, which finds the occurrences of many patterns at once.
+It's an automaton built from the trie of the patterns, in which a failure transition leads from each state
+to the state of its longest proper suffix in the trie. It finds all occurrences of the patterns in a text of length n
+in O(n+k) time, where k is the number of the occurrences, regardless of the number of the patterns.
+The empty patterns never match.
+An AhoCorasick is safe for concurrent use by multiple goroutines.
+
+See A. V. Aho and M. J. Corasick, "Efficient string matching: an aid to bibliographic search", 1975.
+*/
+type AhoCorasick struct {
+	kind   MatchKind
+	fold   [256]byte // maps the bytes to the bytes of the trie
+	maxLen int       // the length of the longest pattern
+
+	// The states of the trie, with the root 0.
+	depth    []int   // the length of the prefix of a pattern of the state
+	out      []int32 // the smallest index of the pattern of the state, or -1
+	outLink  []int32 // the first state with a pattern among the state and the states of its failure chain, or -1
+	dictLink []int32 // the outLink of the failure state of the state
+
+	next   []int32 // next[i] is the index of the next pattern equal to the pattern i, or -1
+	length []int   // the lengths of the patterns
+
+	dense []int32 // the transitions of a DenseTable: dense[s<<8|b] is the next state of the state s
+	da    *doubleArray
+}
+
+// doubleArray stores the transitions of a trie in the double-array layout.
+// The states are at the indexes of the arrays, with the root at 0, and there is a transition from the state s
+// by the byte b to the state t = base[s]+b if check[t] == s.
+type doubleArray struct {
+	base  []int32
+	check []int32
+	fail  []int32 // the failure states
+	state []int32 // the states of the trie, or -1 for unused indexes
+}
+
+// NewAhoCorasick returns the Aho–Corasick automaton of the patterns.
+// It runs in O(m) time for DenseTable, where m is the total length of the patterns,
+// and in O(m*s) time in the worst case for DoubleArray, where s is the number of the states.
+// If opts is nil, the default options are used.
+func NewAhoCorasick(patterns []string, opts *AhoCorasickOptions) *AhoCorasick {
+	var o AhoCorasickOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	ac := &AhoCorasick{
+		kind:   o.MatchKind,
+		depth:  []int{0},
+		out:    []int32{-1},
+		next:   make([]int32, len(patterns)),
+		length: make([]int, len(patterns)),
+	}
+	for b := range ac.fold {
+		ac.fold[b] = byte(b)
+		if o.CaseInsensitive && 'A' <= b && b <= 'Z' {
+			ac.fold[b] = byte(b) + 'a' - 'A'
+		}
+	}
+
+	// Build the trie.
+	children := []map[byte]int32{{}}
+	for i, p := range patterns {
+		ac.next[i] = -1
+		ac.length[i] = len(p)
+		ac.maxLen = max(ac.maxLen, len(p))
+		if p == "" {
+			continue
+		}
+
+		s := int32(0)
+		for j := 0; j < len(p); j++ {
+			b := ac.fold[p[j]]
+			t, ok := children[s][b]
+			if !ok {
+				t = int32(len(children))
+				children[s][b] = t
+				children = append(children, map[byte]int32{})
+				ac.depth = append(ac.depth, j+1)
+				ac.out = append(ac.out, -1)
+			}
+			s = t
+		}
+
+		// Append the pattern to the list of the equal patterns, so the first one has the smallest index.
+		if ac.out[s] < 0 {
+			ac.out[s] = int32(i)
+		} else {
+			k := ac.out[s]
+			for ac.next[k] >= 0 {
+				k = ac.next[k]
+			}
+			ac.next[k] = int32(i)
+		}
+	}
+
+	// Compute the failure links in the BFS order, so the failure state of each state precedes it.
+	n := len(children)
+	fail := make([]int32, n)
+	ac.outLink = make([]int32, n)
+	ac.dictLink = make([]int32, n)
+	ac.outLink[0], ac.dictLink[0] = -1, -1
+	order := make([]int32, 1, n)
+	labels := make([][]byte, n)
+	for k := 0; k < len(order); k++ {
+		s := order[k]
+		for b := range children[s] {
+			labels[s] = append(labels[s], b)
+		}
+		slices.Sort(labels[s])
+
+		for _, b := range labels[s] {
+			t := children[s][b]
+			order = append(order, t)
+
+			if s != 0 {
+				f := fail[s]
+				for {
+					if u, ok := children[f][b]; ok {
+						fail[t] = u
+						break
+					}
+					if f == 0 {
+						break
+					}
+					f = fail[f]
+				}
+			}
+
+			ac.dictLink[t] = ac.outLink[fail[t]]
+			ac.outLink[t] = ac.dictLink[t]
+			if ac.out[t] >= 0 {
+				ac.outLink[t] = t
+			}
+		}
+	}
+
+	switch o.Representation {
+	case DoubleArray:
+		ac.da = newDoubleArray(children, labels, order, fail)
+	default:
+		ac.dense = make([]int32, n<<8)
+		for _, s := range order {
+			row := ac.dense[int(s)<<8 : int(s+1)<<8]
+			for b := range row {
+				if t, ok := children[s][ac.fold[b]]; ok {
+					row[b] = t
+				} else if s != 0 {
+					row[b] = ac.dense[int(fail[s])<<8|int(ac.fold[b])]
+				}
+			}
+		}
+	}
+
+	return ac
+}
+
+// newDoubleArray returns the double array of the trie with the children, the sorted labels of the children,
+// the BFS order of the states and the failure states.
+func newDoubleArray(children []map[byte]int32, labels [][]byte, order, fail []int32) *doubleArray {
+	da := &doubleArray{}
+	pos := make([]int32, len(children)) // the indexes of the states in the double array
+	grow := func(n int) {
+		for len(da.check) < n {
+			da.base = append(da.base, 0)
+			da.check = append(da.check, -1)
+			da.fail = append(da.fail, 0)
+			da.state = append(da.state, -1)
+		}
+	}
+	grow(1)
+	da.check[0], da.state[0] = -2, 0 // the root is never a target of a transition
+
+	free := 1 // all indexes before free are used
+	for _, s := range order {
+		ls := labels[s]
+		if len(ls) == 0 {
+			continue
+		}
+
+		// Find the first base at which all children fit into unused indexes.
+		base := max(1, free-int(ls[0]))
+	search:
+		for ; ; base++ {
+			grow(base + int(ls[len(ls)-1]) + 1)
+			for _, b := range ls {
+				if da.check[base+int(b)] != -1 {
+					continue search
+				}
+			}
+			break
+		}
+
+		p := pos[s]
+		da.base[p] = int32(base)
+		for _, b := range ls {
+			t := children[s][b]
+			q := int32(base + int(b))
+			pos[t] = q
+			da.check[q] = p
+			da.state[q] = t
+		}
+		for free < len(da.check) && da.check[free] != -1 {
+			free++
+		}
+	}
+
+	for s, p := range pos {
+		da.fail[p] = pos[fail[s]]
+	}
+	return da
+}
+
+// Len returns the number of the patterns.
+func (ac *AhoCorasick) Len() int {
+	return len(ac.length)
+}
+
+// Find calls fn with the matches of the patterns in the text in the order of their ends until fn returns false.
+func (ac *AhoCorasick) Find(text []byte, fn func(m Match) bool) {
+	sc := &acScanner{ac: ac, fn: fn}
+	sc.run(text, 0)
+	sc.finish(text)
+}
+
+// FindString is the same as [AhoCorasick.Find], but searches in a string.
+func (ac *AhoCorasick) FindString(text string, fn func(m Match) bool) {
+	ac.Find(bytesOf(text), fn)
+}
+
+// FindAll returns the matches of the patterns in the text in the order of their ends.
+func (ac *AhoCorasick) FindAll(text []byte) []Match {
+	var ms []Match
+	ac.Find(text, func(m Match) bool {
+		ms = append(ms, m)
+		return true
+	})
+	return ms
+}
+
+// FindReader is the same as [AhoCorasick.Find], but searches in the stream read from r.
+// The offsets of the matches are relative to the start of the stream.
+// It reads the stream in chunks, keeping at most the length of the longest pattern bytes of the previous chunks,
+// so the matches spanning the chunks are found using constant memory.
+// It returns the first error returned by r other than io.EOF.
+func (ac *AhoCorasick) FindReader(r io.Reader, fn func(m Match) bool) error {
+	sc := &acScanner{ac: ac, fn: fn}
+	buf := make([]byte, 0, ac.maxLen+ahoCorasickChunk)
+	for {
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		sc.run(buf, len(buf)-n)
+		if sc.stopped {
+			return nil
+		}
+		if err == io.EOF {
+			sc.finish(buf)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Keep the bytes that may be scanned again for the leftmost-longest matches.
+		keep := 0
+		if ac.kind == LeftmostLongestMatch {
+			keep = min(len(buf), ac.maxLen)
+		}
+		drop := len(buf) - keep
+		copy(buf, buf[drop:])
+		buf = buf[:keep]
+		sc.base += drop
+	}
+}
+
+// acScanner holds the state of a search of [AhoCorasick] in a text scanned in one or more chunks.
+type acScanner struct {
+	ac      *AhoCorasick
+	fn      func(m Match) bool
+	stopped bool
+
+	state int32 // the state of the dense table or the index of the double array
+	base  int   // the offset of the buffer in the text
+
+	// The leftmost-longest match found so far, which may be replaced by a match starting earlier or ending later.
+	pending    Match
+	hasPending bool
+}
+
+// run scans buf[from:], where buf[:from] has been scanned already.
+func (sc *acScanner) run(buf []byte, from int) {
+	ac := sc.ac
+	s := sc.state
+
+	if ac.da == nil {
+		for i := from; i < len(buf); {
+			s = ac.dense[int(s)<<8|int(buf[i])]
+			i++
+			if ac.outLink[s] < 0 && !sc.hasPending {
+				continue
+			}
+
+			sc.state = s
+			if i = sc.match(s, buf, i); sc.stopped {
+				return
+			}
+			s = sc.state
+		}
+		sc.state = s
+		return
+	}
+
+	da := ac.da
+	for i := from; i < len(buf); {
+		b := int32(ac.fold[buf[i]])
+		for {
+			if t := da.base[s] + b; int(t) < len(da.check) && da.check[t] == s {
+				s = t
+				break
+			}
+			if s == 0 {
+				break
+			}
+			s = da.fail[s]
+		}
+		i++
+		st := da.state[s]
+		if ac.outLink[st] < 0 && !sc.hasPending {
+			continue
+		}
+
+		sc.state = s
+		if i = sc.match(st, buf, i); sc.stopped {
+			return
+		}
+		s = sc.state
+	}
+	sc.state = s
+}
+
+// match handles the state st of the trie reached after scanning buf[:i], and returns the index of the buffer to continue from.
+func (sc *acScanner) match(st int32, buf []byte, i int) int {
+	ac := sc.ac
+	end := sc.base + i
+
+	if ac.kind != LeftmostLongestMatch {
+		for t := ac.outLink[st]; t >= 0; t = ac.dictLink[t] {
+			for p := ac.out[t]; p >= 0; p = ac.next[p] {
+				if !sc.fn(Match{Pattern: int(p), Start: end - ac.length[p], End: end}) {
+					sc.stopped = true
+					return i
+				}
+			}
+		}
+		return i
+	}
+
+	// No match starting before or at the pending one can be found after the state no longer contains its start.
+	if sc.hasPending && end-ac.depth[st] > sc.pending.Start {
+		return sc.flush()
+	}
+	// The matches ending here start after the first one, so only the first one can replace the pending match.
+	if t := ac.outLink[st]; t >= 0 {
+		if start := end - ac.depth[t]; !sc.hasPending || start <= sc.pending.Start {
+			sc.pending = Match{Pattern: int(ac.out[t]), Start: start, End: end}
+			sc.hasPending = true
+		}
+	}
+	return i
+}
+
+// flush reports the pending leftmost-longest match and returns the index of the buffer at its end,
+// from which the search restarts, since the matches overlapping it must be ignored.
+func (sc *acScanner) flush() int {
+	m := sc.pending
+	sc.hasPending = false
+	if !sc.fn(m) {
+		sc.stopped = true
+	}
+	sc.state = 0
+	return m.End - sc.base
+}
+
+// finish reports the remaining matches at the end of the text, the end of which is in buf.
+func (sc *acScanner) finish(buf []byte) {
+	for sc.hasPending && !sc.stopped {
+		sc.run(buf, sc.flush())
+	}
+}
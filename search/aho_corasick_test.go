@@ -0,0 +1,275 @@
+package search
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// naiveMatches returns the matches of the patterns in the text of the kind in the order reported by AhoCorasick.
+func naiveMatches(text string, patterns []string, kind MatchKind, caseInsensitive bool) []Match {
+	if caseInsensitive {
+		text = asciiLower(text)
+		patterns = slices.Clone(patterns)
+		for i := range patterns {
+			patterns[i] = asciiLower(patterns[i])
+		}
+	}
+
+	var all []Match
+	for id, p := range patterns {
+		for i := 0; p != "" && i+len(p) <= len(text); i++ {
+			if text[i:i+len(p)] == p {
+				all = append(all, Match{Pattern: id, Start: i, End: i + len(p)})
+			}
+		}
+	}
+	if kind == OverlappingMatch {
+		slices.SortFunc(all, func(a, b Match) int {
+			return cmp.Or(cmp.Compare(a.End, b.End), cmp.Compare(a.Start, b.Start), cmp.Compare(a.Pattern, b.Pattern))
+		})
+		return all
+	}
+
+	// The leftmost-longest matches: the first start, then the longest, then the smallest pattern index.
+	slices.SortFunc(all, func(a, b Match) int {
+		return cmp.Or(cmp.Compare(a.Start, b.Start), cmp.Compare(b.End, a.End), cmp.Compare(a.Pattern, b.Pattern))
+	})
+	var ms []Match
+	for _, m := range all {
+		if len(ms) == 0 || m.Start >= ms[len(ms)-1].End {
+			ms = append(ms, m)
+		}
+	}
+	return ms
+}
+
+// asciiLower returns s with the ASCII letters in lower case.
+func asciiLower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if 'A' <= c && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
+
+// chunkReader returns the bytes of r in chunks of random lengths up to n.
+type chunkReader struct {
+	r io.Reader
+	n int
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	return r.r.Read(p[:min(len(p), 1+rand.Intn(r.n))])
+}
+
+// testAhoCorasick checks all the ways of searching the text with all the options against the naive search.
+func testAhoCorasick(t *testing.T, text string, patterns []string) {
+	t.Helper()
+
+	for _, kind := range []MatchKind{OverlappingMatch, LeftmostLongestMatch} {
+		for _, ci := range []bool{false, true} {
+			want := naiveMatches(text, patterns, kind, ci)
+			for _, rep := range []Representation{DenseTable, DoubleArray} {
+				opts := &AhoCorasickOptions{MatchKind: kind, CaseInsensitive: ci, Representation: rep}
+				ac := NewAhoCorasick(patterns, opts)
+
+				if ms := ac.FindAll([]byte(text)); !slices.Equal(ms, want) {
+					t.Fatalf("%+v: FindAll(%q, %q) = %v; want %v", *opts, text, patterns, ms, want)
+				}
+
+				var ms []Match
+				ac.FindString(text, func(m Match) bool {
+					ms = append(ms, m)
+					return true
+				})
+				if !slices.Equal(ms, want) {
+					t.Fatalf("%+v: FindString(%q, %q) = %v; want %v", *opts, text, patterns, ms, want)
+				}
+
+				for _, r := range []io.Reader{
+					strings.NewReader(text),
+					iotest.OneByteReader(strings.NewReader(text)),
+					iotest.DataErrReader(&chunkReader{strings.NewReader(text), 7}),
+				} {
+					ms = ms[:0]
+					err := ac.FindReader(r, func(m Match) bool {
+						ms = append(ms, m)
+						return true
+					})
+					if err != nil || !slices.Equal(ms, want) {
+						t.Fatalf("%+v: FindReader(%q, %q) = %v, %v; want %v", *opts, text, patterns, ms, err, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestAhoCorasick(t *testing.T) {
+	tests := map[string]struct {
+		text     string
+		patterns []string
+	}{
+		"empty":            {"", nil},
+		"no patterns":      {"abc", nil},
+		"empty pattern":    {"abc", []string{""}},
+		"empty text":       {"", []string{"a"}},
+		"classic":          {"ushers", []string{"he", "she", "his", "hers"}},
+		"nested":           {"abcd", []string{"abcd", "bc", "b", "abc", "d"}},
+		"duplicates":       {"xabcabx", []string{"ab", "abc", "ab", "b"}},
+		"overlapping":      {"aaaaa", []string{"aa", "aaa"}},
+		"leftmost longest": {"abcd", []string{"bcd", "abc", "d"}},
+		"longer later":     {"abcde", []string{"ab", "abcdef", "cde", "e"}},
+		"case":             {"The Quick BROWN fox", []string{"quick", "Brown", "FOX", "the"}},
+		"binary":           {"\x00\xff\x00\xff\xff", []string{"\x00\xff", "\xff\xff", "\xff"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testAhoCorasick(t, tt.text, tt.patterns)
+		})
+	}
+}
+
+func TestAhoCorasick_Random(t *testing.T) {
+	for _, k := range []int{2, 3, 26} {
+		t.Run(fmt.Sprint(k), func(t *testing.T) {
+			for i := 0; i < 200; i++ {
+				text := string(randomText(rand.Intn(200), k))
+				patterns := make([]string, rand.Intn(20))
+				for j := range patterns {
+					patterns[j] = string(randomText(rand.Intn(8), k))
+					if rand.Intn(2) == 0 {
+						patterns[j] = strings.ToUpper(patterns[j])
+					}
+				}
+				testAhoCorasick(t, text, patterns)
+			}
+		})
+	}
+}
+
+func TestAhoCorasick_Stop(t *testing.T) {
+	for _, kind := range []MatchKind{OverlappingMatch, LeftmostLongestMatch} {
+		for _, rep := range []Representation{DenseTable, DoubleArray} {
+			ac := NewAhoCorasick([]string{"a", "aa"}, &AhoCorasickOptions{MatchKind: kind, Representation: rep})
+
+			var ms []Match
+			err := ac.FindReader(strings.NewReader(strings.Repeat("a", 100)), func(m Match) bool {
+				ms = append(ms, m)
+				return len(ms) < 3
+			})
+			if err != nil || len(ms) != 3 {
+				t.Errorf("%v, %v: FindReader reported %d matches, %v; want 3 matches before stopping", kind, rep, len(ms), err)
+			}
+		}
+	}
+}
+
+func TestAhoCorasick_ReaderError(t *testing.T) {
+	ac := NewAhoCorasick([]string{"ab"}, nil)
+	errRead := errors.New("read error")
+
+	var ms []Match
+	err := ac.FindReader(io.MultiReader(strings.NewReader("xabx"), iotest.ErrReader(errRead)), func(m Match) bool {
+		ms = append(ms, m)
+		return true
+	})
+	if !errors.Is(err, errRead) {
+		t.Errorf("FindReader returned %v; want %v", err, errRead)
+	}
+	if want := []Match{{0, 1, 3}}; !slices.Equal(ms, want) {
+		t.Errorf("FindReader reported %v before the error; want %v", ms, want)
+	}
+}
+
+func TestAhoCorasick_LongStream(t *testing.T) {
+	// The matches span the chunks of the stream.
+	patterns := []string{strings.Repeat("ab", 100), "ba", strings.Repeat("b", 50)}
+	text := strings.Repeat(strings.Repeat("ab", 150)+strings.Repeat("b", 60), 300)
+
+	for _, kind := range []MatchKind{OverlappingMatch, LeftmostLongestMatch} {
+		want := naiveMatches(text, patterns, kind, false)
+		ac := NewAhoCorasick(patterns, &AhoCorasickOptions{MatchKind: kind})
+
+		var ms []Match
+		if err := ac.FindReader(strings.NewReader(text), func(m Match) bool {
+			ms = append(ms, m)
+			return true
+		}); err != nil || !slices.Equal(ms, want) {
+			t.Errorf("%v: FindReader found %d matches, %v; want %d", kind, len(ms), err, len(want))
+		}
+	}
+}
+
+func TestMatchKind_String(t *testing.T) {
+	if s := LeftmostLongestMatch.String(); s != "LeftmostLongest" {
+		t.Errorf("LeftmostLongestMatch.String() = %q; want %q", s, "LeftmostLongest")
+	}
+	if s := Representation(5).String(); s != "Representation(5)" {
+		t.Errorf("Representation(5).String() = %q; want %q", s, "Representation(5)")
+	}
+}
+
+func FuzzAhoCorasick(f *testing.F) {
+	f.Add("ushers", "he she his hers", false)
+	f.Fuzz(func(t *testing.T, text, patterns string, leftmost bool) {
+		ps := strings.Split(patterns, " ")
+		kind := OverlappingMatch
+		if leftmost {
+			kind = LeftmostLongestMatch
+		}
+		want := naiveMatches(text, ps, kind, false)
+
+		for _, rep := range []Representation{DenseTable, DoubleArray} {
+			ac := NewAhoCorasick(ps, &AhoCorasickOptions{MatchKind: kind, Representation: rep})
+			if ms := ac.FindAll([]byte(text)); !slices.Equal(ms, want) {
+				t.Errorf("%v, %v: FindAll(%q, %q) = %v; want %v", kind, rep, text, ps, ms, want)
+			}
+		}
+	})
+}
+
+func BenchmarkAhoCorasick(b *testing.B) {
+	// Thousands of keywords in a text of words.
+	words := make([]string, 5000)
+	for i := range words {
+		words[i] = string(randomText(4+rand.Intn(8), 26))
+	}
+	var text bytes.Buffer
+	for text.Len() < 1<<20 {
+		text.WriteString(words[rand.Intn(len(words))])
+		text.WriteByte(' ')
+		text.Write(randomText(rand.Intn(10), 26))
+		text.WriteByte(' ')
+	}
+	keywords := words[:1000]
+
+	for _, rep := range []Representation{DenseTable, DoubleArray} {
+		ac := NewAhoCorasick(keywords, &AhoCorasickOptions{Representation: rep})
+		b.Run(fmt.Sprint(rep), func(b *testing.B) {
+			b.SetBytes(int64(text.Len()))
+			for i := 0; i < b.N; i++ {
+				ac.Find(text.Bytes(), func(Match) bool { return true })
+			}
+		})
+	}
+	b.Run("bytes.Index", func(b *testing.B) {
+		b.SetBytes(int64(text.Len()))
+		for i := 0; i < b.N; i++ {
+			for _, k := range keywords {
+				bytes.Index(text.Bytes(), []byte(k))
+			}
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package search
+
+import "cmp"
+
+/*
+LowerBound implements binary search. It returns the index of the first element in the slice that is not less than the target.
+If all elements are less than the target, it returns len(x).
+Unlike [Binary], it always returns the first of multiple elements equal to the target.
+The slice must be sorted in increasing order.
+*/
+func LowerBound[E cmp.Ordered](x []E, target E) int {
+	lo, hi := 0, len(x)
+
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+
+		if x[mid] < target {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	return lo
+}
+
+/*
+LowerBoundCmp implements binary search. It returns the index of the first element in the slice that is not less than the target.
+If all elements are less than the target, it returns len(x).
+Unlike [BinaryCmp], it always returns the first of multiple elements equal to the target.
+The slice must be sorted in increasing order, defined by the comparison function cmp.
+*/
+func LowerBoundCmp[T any](x []T, target T, cmp func(x, y T) int) int {
+	lo, hi := 0, len(x)
+
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+
+		if cmp(x[mid], target) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	return lo
+}
+
+/*
+UpperBound implements binary search. It returns the index of the first element in the slice that is greater than the target.
+If no element is greater than the target, it returns len(x).
+The slice must be sorted in increasing order.
+*/
+func UpperBound[E cmp.Ordered](x []E, target E) int {
+	lo, hi := 0, len(x)
+
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+
+		if x[mid] <= target {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	return lo
+}
+
+/*
+UpperBoundCmp implements binary search. It returns the index of the first element in the slice that is greater than the target.
+If no element is greater than the target, it returns len(x).
+The slice must be sorted in increasing order, defined by the comparison function cmp.
+*/
+func UpperBoundCmp[T any](x []T, target T, cmp func(x, y T) int) int {
+	lo, hi := 0, len(x)
+
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+
+		if cmp(x[mid], target) <= 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	return lo
+}
+
+/*
+EqualRange returns the range [lo, hi) of the elements in the slice equal to the target,
+i.e. the results of [LowerBound] and [UpperBound].
+If there are no such elements, lo == hi is the index where the target should be inserted.
+The slice must be sorted in increasing order.
+*/
+func EqualRange[E cmp.Ordered](x []E, target E) (lo, hi int) {
+	lo = LowerBound(x, target)
+	hi = lo + UpperBound(x[lo:], target)
+	return lo, hi
+}
+
+/*
+EqualRangeCmp returns the range [lo, hi) of the elements in the slice equal to the target,
+i.e. the results of [LowerBoundCmp] and [UpperBoundCmp].
+If there are no such elements, lo == hi is the index where the target should be inserted.
+The slice must be sorted in increasing order, defined by the comparison function cmp.
+*/
+func EqualRangeCmp[T any](x []T, target T, cmp func(x, y T) int) (lo, hi int) {
+	lo = LowerBoundCmp(x, target, cmp)
+	hi = lo + UpperBoundCmp(x[lo:], target, cmp)
+	return lo, hi
+}
+
+/*
+CountInRange returns the number of elements v in the slice such that from <= v < to.
+If from >= to, it returns 0.
+The slice must be sorted in increasing order.
+*/
+func CountInRange[E cmp.Ordered](x []E, from, to E) int {
+	if !(from < to) {
+		return 0
+	}
+	lo := LowerBound(x, from)
+	return LowerBound(x[lo:], to)
+}
+
+/*
+CountInRangeCmp returns the number of elements v in the slice such that from <= v < to.
+If from >= to, it returns 0.
+The slice must be sorted in increasing order, defined by the comparison function cmp.
+*/
+func CountInRangeCmp[T any](x []T, from, to T, cmp func(x, y T) int) int {
+	if cmp(from, to) >= 0 {
+		return 0
+	}
+	lo := LowerBoundCmp(x, from, cmp)
+	return LowerBoundCmp(x[lo:], to, cmp)
+}
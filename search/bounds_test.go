@@ -0,0 +1,118 @@
+package search
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestLowerUpperBound(t *testing.T) {
+	tests := map[string]struct {
+		x      []int
+		target int
+		lo, hi int
+	}{
+		"[]; 0":         {genArr(0), 0, 0, 0},
+		"[0]; 0":        {genArr(1), 0, 0, 1},
+		"[0]; 1":        {genArr(1), 1, 1, 1},
+		"[0]; -1":       {genArr(1), -1, 0, 0},
+		"[0..99]; 50":   {genArr(100), 50, 50, 51},
+		"[0..99]; 150":  {genArr(100), 150, 100, 100},
+		"[0..99]; -150": {genArr(100), -150, 0, 0},
+		"data; -10":     {data, -10, 0, 1},
+		"data; 4":       {data, 4, 6, 6},
+		"data; 99":      {data, 99, 9, 9},
+		"data; 100":     {data, 100, 9, 12},
+		"data; 101":     {data, 101, 12, 12},
+		"data; 10000":   {data, 10000, 13, 14},
+		"dups; 1":       {[]int{1, 1, 1, 1, 1}, 1, 0, 5},
+		"dups; 0":       {[]int{1, 1, 1, 1, 1}, 0, 0, 0},
+		"dups; 2":       {[]int{1, 1, 1, 1, 1}, 2, 5, 5},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if lo := LowerBound(tt.x, tt.target); lo != tt.lo {
+				t.Errorf("LowerBound got %d; want %d", lo, tt.lo)
+			}
+			if lo := LowerBoundCmp(tt.x, tt.target, cmp.Compare[int]); lo != tt.lo {
+				t.Errorf("LowerBoundCmp got %d; want %d", lo, tt.lo)
+			}
+			if hi := UpperBound(tt.x, tt.target); hi != tt.hi {
+				t.Errorf("UpperBound got %d; want %d", hi, tt.hi)
+			}
+			if hi := UpperBoundCmp(tt.x, tt.target, cmp.Compare[int]); hi != tt.hi {
+				t.Errorf("UpperBoundCmp got %d; want %d", hi, tt.hi)
+			}
+			if lo, hi := EqualRange(tt.x, tt.target); lo != tt.lo || hi != tt.hi {
+				t.Errorf("EqualRange got %d, %d; want %d, %d", lo, hi, tt.lo, tt.hi)
+			}
+			if lo, hi := EqualRangeCmp(tt.x, tt.target, cmp.Compare[int]); lo != tt.lo || hi != tt.hi {
+				t.Errorf("EqualRangeCmp got %d, %d; want %d, %d", lo, hi, tt.lo, tt.hi)
+			}
+		})
+	}
+}
+
+func TestCountInRange(t *testing.T) {
+	tests := map[string]struct {
+		x        []int
+		from, to int
+		n        int
+	}{
+		"[]; [0, 1)":          {genArr(0), 0, 1, 0},
+		"[0..99]; [10, 20)":   {genArr(100), 10, 20, 10},
+		"[0..99]; [-10, 200)": {genArr(100), -10, 200, 100},
+		"[0..99]; [20, 10)":   {genArr(100), 20, 10, 0},
+		"[0..99]; [10, 10)":   {genArr(100), 10, 10, 0},
+		"data; [100, 101)":    {data, 100, 101, 3},
+		"data; [100, 1000)":   {data, 100, 1000, 3},
+		"data; [100, 1001)":   {data, 100, 1001, 4},
+		"data; [-5, 3)":       {data, -5, 3, 4},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if n := CountInRange(tt.x, tt.from, tt.to); n != tt.n {
+				t.Errorf("CountInRange got %d; want %d", n, tt.n)
+			}
+			if n := CountInRangeCmp(tt.x, tt.from, tt.to, cmp.Compare[int]); n != tt.n {
+				t.Errorf("CountInRangeCmp got %d; want %d", n, tt.n)
+			}
+		})
+	}
+}
+
+func FuzzEqualRange(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s []byte, target byte) {
+		slices.Sort(s)
+
+		lo, hi := EqualRange(s, target)
+
+		if lo > hi || lo > 0 && s[lo-1] >= target || hi < len(s) && s[hi] <= target {
+			t.Errorf("EqualRange(%v, %d) = %d, %d", s, target, lo, hi)
+		}
+		for _, v := range s[lo:hi] {
+			if v != target {
+				t.Errorf("EqualRange(%v, %d) = %d, %d", s, target, lo, hi)
+			}
+		}
+	})
+}
+
+func FuzzCountInRange(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s []byte, from, to byte) {
+		slices.Sort(s)
+
+		want := 0
+		for _, v := range s {
+			if from <= v && v < to {
+				want++
+			}
+		}
+
+		if n := CountInRange(s, from, to); n != want {
+			t.Errorf("CountInRange(%v, %d, %d) = %d; want %d", s, from, to, n, want)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package search
+
+// boyerMoore implements the Boyer–Moore algorithm with the bad character and the good suffix rules.
+// It runs in O(n*m) time in the worst case and in O(n/m) time on average on random texts,
+// where n and m are the lengths of the text and the pattern.
+//
+// See C. Charras and T. Lecroq, "Handbook of Exact String Matching Algorithms", 2004.
+type boyerMoore[T comparable] struct {
+	pattern []T
+	bc      *shiftTable[T]
+	// gs[i] is the shift of the good suffix rule after a mismatch at index i of the pattern.
+	gs []int
+}
+
+func newBoyerMoore[T comparable](pattern []T) *boyerMoore[T] {
+	m := len(pattern)
+
+	// suff[i] is the length of the longest common suffix of pattern[:i+1] and the pattern.
+	suff := make([]int, m)
+	suff[m-1] = m
+	g, f := m-1, 0
+	for i := m - 2; i >= 0; i-- {
+		if i > g && suff[i+m-1-f] < i-g {
+			suff[i] = suff[i+m-1-f]
+			continue
+		}
+		g = min(g, i)
+		f = i
+		for g >= 0 && pattern[g] == pattern[g+m-1-f] {
+			g--
+		}
+		suff[i] = f - g
+	}
+
+	gs := make([]int, m)
+	for i := range gs {
+		gs[i] = m
+	}
+	// The suffixes of the pattern that are also its prefixes.
+	for i, j := m-1, 0; i >= 0; i-- {
+		if suff[i] == i+1 {
+			for ; j < m-1-i; j++ {
+				if gs[j] == m {
+					gs[j] = m - 1 - i
+				}
+			}
+		}
+	}
+	// The other occurrences of the suffixes of the pattern.
+	for i := 0; i < m-1; i++ {
+		gs[m-1-suff[i]] = m - 1 - i
+	}
+
+	return &boyerMoore[T]{pattern: pattern, bc: badCharacterTable(pattern), gs: gs}
+}
+
+func (p *boyerMoore[T]) scan(text []T, overlap bool, yield func(i int) bool) {
+	m, n := len(p.pattern), len(text)
+	for j := 0; j <= n-m; {
+		i := m - 1
+		for i >= 0 && p.pattern[i] == text[i+j] {
+			i--
+		}
+
+		if i >= 0 {
+			j += max(p.gs[i], p.bc.get(text[i+j])-m+1+i)
+			continue
+		}
+		if !yield(j) {
+			return
+		}
+		if overlap {
+			// The shift of the good suffix rule of the whole pattern is its period.
+			j += p.gs[0]
+		} else {
+			j += m
+		}
+	}
+}
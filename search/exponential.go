@@ -0,0 +1,100 @@
+package search
+
+import "cmp"
+
+/*
+Exponential implements exponential (galloping) search. It returns the index of the first element in the slice that is not less than the target,
+or len(x) if all elements are less than the target.
+It probes the elements with indexes 0, 1, 2, 4, 8, ... until it passes the target and then uses binary search in the last interval,
+so it runs in O(log(i)) time, where i is the returned index, which is faster than [LowerBound] if the target is near the beginning of the slice.
+The slice must be sorted in increasing order.
+*/
+func Exponential[E cmp.Ordered](x []E, target E) int {
+	return ExponentialFromCmp(x, target, 0, cmp.Compare[E])
+}
+
+/*
+ExponentialCmp is the same as [Exponential], but the slice must be sorted in increasing order, defined by the comparison function cmp.
+*/
+func ExponentialCmp[T any](x []T, target T, cmp func(x, y T) int) int {
+	return ExponentialFromCmp(x, target, 0, cmp)
+}
+
+/*
+ExponentialFrom implements exponential (galloping) search starting at index hint. It returns the index of the first element in the slice that is not less than the target,
+or len(x) if all elements are less than the target.
+It gallops from hint towards the target in either direction, so it runs in O(log(d)) time, where d is the distance between hint and the returned index.
+For example, hint len(x)-1 finds the targets near the end of the slice quickly.
+The hint is clamped to the interval [0, len(x)-1].
+The slice must be sorted in increasing order.
+*/
+func ExponentialFrom[E cmp.Ordered](x []E, target E, hint int) int {
+	return ExponentialFromCmp(x, target, hint, cmp.Compare[E])
+}
+
+/*
+ExponentialFromCmp is the same as [ExponentialFrom], but the slice must be sorted in increasing order, defined by the comparison function cmp.
+*/
+func ExponentialFromCmp[T any](x []T, target T, hint int, cmp func(x, y T) int) int {
+	n := len(x)
+	if n == 0 {
+		return 0
+	}
+	hint = min(max(hint, 0), n-1)
+
+	// Find the interval (lo, hi] containing the result, where x[lo] < target <= x[hi],
+	// treating x[-1] as less than and x[n] as not less than any target.
+	var lo, hi int
+	if cmp(x[hint], target) < 0 {
+		lo, hi = hint, hint+1
+		for step := 1; hi < n && cmp(x[hi], target) < 0; step *= 2 {
+			lo, hi = hi, hi+step
+		}
+		hi = min(hi, n)
+	} else {
+		lo, hi = hint-1, hint
+		for step := 1; lo >= 0 && cmp(x[lo], target) >= 0; step *= 2 {
+			lo, hi = lo-step, lo
+		}
+		lo = max(lo, -1)
+	}
+
+	return lo + 1 + LowerBoundCmp(x[lo+1:hi], target, cmp)
+}
+
+/*
+ExponentialFunc implements exponential (galloping) search on a sequence of unknown length accessed using the function at.
+The function at returns the element with index i and true, or false if the sequence ends before index i.
+It returns the index of the first element in the sequence that is not less than the target,
+or the length of the sequence if all elements are less than the target.
+It runs in O(log(i)) time, where i is the returned index, and calls at O(log(i)) times with indexes up to 2*i.
+The sequence must be sorted in increasing order, defined by the comparison function cmp.
+*/
+func ExponentialFunc[T any](at func(i int) (T, bool), target T, cmp func(x, y T) int) int {
+	// less reports whether the element with index i exists and is less than the target.
+	less := func(i int) bool {
+		v, ok := at(i)
+		return ok && cmp(v, target) < 0
+	}
+
+	// Find the interval (lo, hi] containing the result, where the element with index lo is less than the target,
+	// and the element with index hi either doesn't exist or is not less than the target.
+	lo, hi := -1, 0
+	for step := 1; less(hi); step *= 2 {
+		lo, hi = hi, hi+step
+	}
+
+	// The same as BinaryPredicate, but on indexes.
+	lo++
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+
+		if less(mid) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	return lo
+}
@@ -0,0 +1,97 @@
+package search
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestExponential(t *testing.T) {
+	arrs := map[string][]int{
+		"[]":      genArr(0),
+		"[0]":     genArr(1),
+		"[0..1]":  genArr(2),
+		"[0..99]": genArr(100),
+		"data":    data,
+		"dups":    {1, 1, 1, 2, 2, 2, 2, 2, 3, 3},
+	}
+
+	for name, x := range arrs {
+		for target := -2; target <= 102; target++ {
+			want := LowerBound(x, target)
+
+			if i := Exponential(x, target); i != want {
+				t.Errorf("%s; %d: Exponential got %d; want %d", name, target, i, want)
+			}
+			if i := ExponentialCmp(x, target, cmp.Compare[int]); i != want {
+				t.Errorf("%s; %d: ExponentialCmp got %d; want %d", name, target, i, want)
+			}
+			for _, hint := range []int{-1, 0, 1, len(x) / 2, len(x) - 1, len(x), len(x) + 10} {
+				if i := ExponentialFrom(x, target, hint); i != want {
+					t.Errorf("%s; %d; hint %d: ExponentialFrom got %d; want %d", name, target, hint, i, want)
+				}
+			}
+
+			at := func(i int) (int, bool) {
+				if i >= len(x) {
+					return 0, false
+				}
+				return x[i], true
+			}
+			if i := ExponentialFunc(at, target, cmp.Compare[int]); i != want {
+				t.Errorf("%s; %d: ExponentialFunc got %d; want %d", name, target, i, want)
+			}
+		}
+	}
+}
+
+func TestExponentialFunc_Unbounded(t *testing.T) {
+	// The squares of all non-negative integers.
+	var maxIndex int
+	at := func(i int) (int, bool) {
+		maxIndex = max(maxIndex, i)
+		return i * i, true
+	}
+
+	for _, target := range []int{0, 1, 2, 99, 100, 101, 1 << 40} {
+		maxIndex = 0
+		i := ExponentialFunc(at, target, cmp.Compare[int])
+
+		if i*i < target || i > 0 && (i-1)*(i-1) >= target {
+			t.Errorf("ExponentialFunc(squares, %d) = %d", target, i)
+		}
+		if maxIndex > 2*i {
+			t.Errorf("ExponentialFunc(squares, %d) accessed index %d; want at most %d", target, maxIndex, 2*i)
+		}
+	}
+}
+
+func FuzzExponentialFrom(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s []byte, target byte, hint int) {
+		slices.Sort(s)
+
+		if i, want := ExponentialFrom(s, target, hint), LowerBound(s, target); i != want {
+			t.Errorf("ExponentialFrom(%v, %d, %d) = %d; want %d", s, target, hint, i, want)
+		}
+	})
+}
+
+func BenchmarkExponentialFrom(b *testing.B) {
+	n := 1 << 24
+	x := genArr(n)
+
+	for _, d := range []int{1, 1 << 4, 1 << 8, 1 << 16} {
+		b.Run(fmt.Sprintf("LowerBound/%d", d), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				LowerBound(x, n-d-rand.Intn(d))
+			}
+		})
+		b.Run(fmt.Sprintf("ExponentialFrom/%d", d), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ExponentialFrom(x, n-d-rand.Intn(d), n-1)
+			}
+		})
+	}
+}
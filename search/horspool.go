@@ -0,0 +1,41 @@
+package search
+
+// horspool implements the Boyer–Moore–Horspool algorithm.
+// It uses only the bad character rule of the last element of the window, so it runs in O(n*m) time in the worst case,
+// but in O(n/m) time on average on random texts over large alphabets, where n and m are the lengths of the text and the pattern.
+type horspool[T comparable] struct {
+	pattern []T
+	shift   *shiftTable[T]
+}
+
+func newHorspool[T comparable](pattern []T) *horspool[T] {
+	return &horspool[T]{pattern: pattern, shift: badCharacterTable(pattern)}
+}
+
+func (p *horspool[T]) scan(text []T, overlap bool, yield func(i int) bool) {
+	m, n := len(p.pattern), len(text)
+	last := p.pattern[m-1]
+	for i := 0; i <= n-m; {
+		c := text[i+m-1]
+		if c == last && equal(text[i:i+m-1], p.pattern[:m-1]) {
+			if !yield(i) {
+				return
+			}
+			if !overlap {
+				i += m
+				continue
+			}
+		}
+		i += p.shift.get(c)
+	}
+}
+
+// equal reports whether the slices of the same length are equal, comparing them from the end.
+func equal[T comparable](x, y []T) bool {
+	for i := len(x) - 1; i >= 0; i-- {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,260 @@
+package search
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// testIndex checks that the index built from the sorted slice x finds the same indexes as LowerBound.
+func testIndex[E cmp.Ordered](t *testing.T, x []E, targets []E) {
+	t.Helper()
+
+	e, s := NewEytzinger(x), NewSTree(x)
+	if e.Len() != len(x) || s.Len() != len(x) {
+		t.Fatalf("Len() = %d, %d; want %d", e.Len(), s.Len(), len(x))
+	}
+
+	got := e.AppendLowerBounds([]int{-1}, targets)
+	if len(got) != len(targets)+1 || got[0] != -1 {
+		t.Fatalf("AppendLowerBounds returned %d results; want %d appended", len(got), len(targets))
+	}
+	for i, target := range targets {
+		want := LowerBound(x, target)
+
+		if i := e.LowerBound(target); i != want {
+			t.Fatalf("Eytzinger(%v).LowerBound(%v) = %d; want %d", x, target, i, want)
+		}
+		if i := got[i+1]; i != want {
+			t.Fatalf("Eytzinger(%v).AppendLowerBounds(%v) = %d; want %d", x, target, i, want)
+		}
+		if i := s.LowerBound(target); i != want {
+			t.Fatalf("STree(%v).LowerBound(%v) = %d; want %d", x, target, i, want)
+		}
+
+		testIterate(t, "Eytzinger.Ascend", x, want, func(fn func(i int, v E) bool) { e.Ascend(target, fn) })
+		testIterate(t, "STree.Ascend", x, want, func(fn func(i int, v E) bool) { s.Ascend(target, fn) })
+	}
+
+	testIterate(t, "Eytzinger.All", x, 0, e.All)
+	testIterate(t, "STree.All", x, 0, s.All)
+}
+
+// testIterate checks that the iteration function of the index of the sorted slice x reports the elements starting from the index lo in order,
+// and stops when fn returns false.
+func testIterate[E cmp.Ordered](t *testing.T, name string, x []E, lo int, iterate func(fn func(i int, v E) bool)) {
+	t.Helper()
+
+	i := lo
+	iterate(func(j int, v E) bool {
+		if j != i || j >= len(x) || v != x[j] {
+			t.Fatalf("%s of %v reported %d, %v; want %d, %v", name, x, j, v, i, x[min(i, len(x)-1)])
+		}
+		i++
+		return true
+	})
+	if i != len(x) {
+		t.Fatalf("%s of %v stopped at %d; want %d", name, x, i, len(x))
+	}
+
+	if lo < len(x) {
+		calls := 0
+		iterate(func(int, E) bool {
+			calls++
+			return false
+		})
+		if calls != 1 {
+			t.Fatalf("%s of %v called fn %d times; want 1 before stopping", name, x, calls)
+		}
+	}
+}
+
+func TestIndex(t *testing.T) {
+	for n := 0; n < 300; n++ {
+		x := make([]int, n)
+		for i := range x {
+			x[i] = 2 * rand.Intn(n+1)
+		}
+		slices.Sort(x)
+
+		targets := make([]int, 0, 2*n+4)
+		for v := -1; v <= 2*n+2; v++ {
+			targets = append(targets, v)
+		}
+		testIndex(t, x, targets)
+	}
+}
+
+func TestIndex_Types(t *testing.T) {
+	for _, n := range []int{1, 2, 63, 64, 65, 1000, 5000} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			// int8 has 64 elements in a node.
+			small := make([]int8, n)
+			for i := range small {
+				small[i] = int8(rand.Intn(256) - 128)
+			}
+			slices.Sort(small)
+			targets := make([]int8, 0, 256)
+			for v := -128; v < 128; v++ {
+				targets = append(targets, int8(v))
+			}
+			testIndex(t, small, targets)
+
+			ints := make([]int32, n)
+			for i := range ints {
+				ints[i] = int32(i)
+			}
+			testIndex(t, ints, append([]int32{-1, int32(n)}, ints...))
+
+			strs := make([]string, n)
+			for i := range strs {
+				strs[i] = fmt.Sprintf("%x", rand.Intn(4*n))
+			}
+			slices.Sort(strs)
+			testIndex(t, strs, append([]string{"", "g", "0", "ff"}, strs...))
+		})
+	}
+}
+
+func TestEytzinger_Rank(t *testing.T) {
+	// The in-order traversal of the tree visits the ranks in order.
+	for n := 0; n < 100; n++ {
+		e := NewEytzinger(make([]int, n))
+
+		var ranks []int
+		var inorder func(k int)
+		inorder = func(k int) {
+			if k > n {
+				return
+			}
+			inorder(2 * k)
+			ranks = append(ranks, e.rank(k))
+			inorder(2*k + 1)
+		}
+		inorder(1)
+
+		for i, r := range ranks {
+			if r != i {
+				t.Fatalf("n = %d: rank of the %d-th node in order = %d; want %d", n, i, r, i)
+			}
+		}
+	}
+}
+
+func FuzzIndex(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s []byte, target byte) {
+		slices.Sort(s)
+
+		want := LowerBound(s, target)
+		if i := NewEytzinger(s).LowerBound(target); i != want {
+			t.Errorf("Eytzinger(%v).LowerBound(%d) = %d; want %d", s, target, i, want)
+		}
+		if i := NewSTree(s).LowerBound(target); i != want {
+			t.Errorf("STree(%v).LowerBound(%d) = %d; want %d", s, target, i, want)
+		}
+	})
+}
+
+func BenchmarkIndex(b *testing.B) {
+	// The targets are generated in advance, so the benchmarks measure the searches only.
+	const queries = 1 << 16
+
+	for _, n := range []int{1e3, 1e4, 1e5, 1e6, 1e7, 1e8} {
+		// The data is built on first use, so the sub-benchmarks filtered out by -bench don't allocate it.
+		data := sync.OnceValues(func() ([]int32, []int32) {
+			x := make([]int32, n)
+			for i := range x {
+				x[i] = int32(2 * i)
+			}
+			targets := make([]int32, queries)
+			for i := range targets {
+				targets[i] = int32(rand.Intn(2 * n))
+			}
+			return x, targets
+		})
+		eytzinger := sync.OnceValue(func() *Eytzinger[int32] {
+			x, _ := data()
+			return NewEytzinger(x)
+		})
+		stree := sync.OnceValue(func() *STree[int32] {
+			x, _ := data()
+			return NewSTree(x)
+		})
+
+		b.Run(fmt.Sprintf("Binary/%d", n), func(b *testing.B) {
+			x, targets := data()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				Binary(x, targets[i%queries])
+			}
+		})
+		b.Run(fmt.Sprintf("LowerBound/%d", n), func(b *testing.B) {
+			x, targets := data()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				LowerBound(x, targets[i%queries])
+			}
+		})
+		b.Run(fmt.Sprintf("Eytzinger/%d", n), func(b *testing.B) {
+			_, targets := data()
+			e := eytzinger()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				e.LowerBound(targets[i%queries])
+			}
+		})
+		b.Run(fmt.Sprintf("EytzingerBatch/%d", n), func(b *testing.B) {
+			_, targets := data()
+			e := eytzinger()
+			res := make([]int, 0, queries)
+			b.ResetTimer()
+			for i := 0; i < b.N; i += queries {
+				res = e.AppendLowerBounds(res[:0], targets[:min(queries, b.N-i)])
+			}
+		})
+		b.Run(fmt.Sprintf("STree/%d", n), func(b *testing.B) {
+			_, targets := data()
+			s := stree()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s.LowerBound(targets[i%queries])
+			}
+		})
+	}
+}
+
+func BenchmarkIndex_All(b *testing.B) {
+	for _, n := range []int{1e3, 1e5, 1e7} {
+		x := make([]int32, n)
+		for i := range x {
+			x[i] = int32(i)
+		}
+		e, s := NewEytzinger(x), NewSTree(x)
+
+		var sum int32
+		add := func(_ int, v int32) bool {
+			sum += v
+			return true
+		}
+		b.Run(fmt.Sprintf("Slice/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j, v := range x {
+					add(j, v)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Eytzinger/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				e.All(add)
+			}
+		})
+		b.Run(fmt.Sprintf("STree/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s.All(add)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package search
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+/*
+Interpolation implements interpolation search. It returns the index of the first element in the slice that is not less than the target,
+or len(x) if all elements are less than the target.
+Instead of the middle, it probes the index estimated by linear interpolation between the values at the ends of the range,
+so it runs in O(log(log(n))) expected time if the values are uniformly distributed.
+To guarantee O(log(n)) time in the worst case, a bisection step is taken whenever an interpolation step doesn't halve the range.
+The slice must be sorted in increasing order and must not contain NaNs.
+*/
+func Interpolation[E Number](x []E, target E) int {
+	lo, hi := 0, len(x)
+	bisect := false
+
+	// The result is in [lo, hi].
+	for lo < hi {
+		n := hi - lo
+
+		var mid int
+		if bisect {
+			mid = int(uint(lo+hi) >> 1)
+		} else {
+			first, last := x[lo], x[hi-1]
+			switch {
+			case target <= first:
+				return lo
+			case target > last:
+				return hi
+			}
+
+			// The values are converted to float64, which may lose precision, but the estimate is only used as a probe.
+			f := (float64(target) - float64(first)) / (float64(last) - float64(first))
+			mid = lo + int(f*float64(n-1))
+			mid = min(max(mid, lo), hi-1)
+		}
+
+		if x[mid] < target {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+
+		bisect = !bisect && hi-lo > n/2
+	}
+
+	return lo
+}
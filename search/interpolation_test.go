@@ -0,0 +1,117 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func testInterpolation[E Number](t *testing.T, x []E, targets []E) {
+	t.Helper()
+
+	slices.Sort(x)
+	for _, target := range targets {
+		if i, want := Interpolation(x, target), LowerBound(x, target); i != want {
+			t.Fatalf("Interpolation(%v) got %d; want %d", target, i, want)
+		}
+	}
+}
+
+func TestInterpolation(t *testing.T) {
+	tests := map[string]struct {
+		x      []int
+		target int
+		ind    int
+	}{
+		"[]; 0":         {genArr(0), 0, 0},
+		"[0]; 0":        {genArr(1), 0, 0},
+		"[0]; 1":        {genArr(1), 1, 1},
+		"[0]; -1":       {genArr(1), -1, 0},
+		"[0..99]; 50":   {genArr(100), 50, 50},
+		"[0..99]; 150":  {genArr(100), 150, 100},
+		"[0..99]; -150": {genArr(100), -150, 0},
+		"data; 100":     {data, 100, 9},
+		"data; 101":     {data, 101, 12},
+		"data; 4":       {data, 4, 6},
+		"dups; 1":       {[]int{1, 1, 1, 1, 1}, 1, 0},
+		"dups; 2":       {[]int{1, 1, 1, 1, 1}, 2, 5},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if ind := Interpolation(tt.x, tt.target); ind != tt.ind {
+				t.Errorf("got %d; want %d", ind, tt.ind)
+			}
+		})
+	}
+}
+
+func TestInterpolation_Distributions(t *testing.T) {
+	n := 10000
+
+	t.Run("uniform", func(t *testing.T) {
+		x, targets := make([]int64, n), make([]int64, n)
+		for i := range x {
+			x[i], targets[i] = rand.Int63n(1<<40), rand.Int63n(1<<40)
+		}
+		testInterpolation(t, x, append(targets, x...))
+	})
+	t.Run("exponential", func(t *testing.T) {
+		x, targets := make([]float64, n), make([]float64, n)
+		for i := range x {
+			x[i], targets[i] = math.Exp(rand.Float64()*100), math.Exp(rand.Float64()*100)
+		}
+		testInterpolation(t, x, append(targets, x...))
+	})
+	t.Run("few unique", func(t *testing.T) {
+		x, targets := make([]uint8, n), make([]uint8, 256)
+		for i := range x {
+			x[i] = uint8(rand.Intn(4)) * 64
+		}
+		for i := range targets {
+			targets[i] = uint8(i)
+		}
+		testInterpolation(t, x, targets)
+	})
+	t.Run("extremes", func(t *testing.T) {
+		x := []int64{math.MinInt64, math.MinInt64, -1, 0, 0, 1, math.MaxInt64 - 1, math.MaxInt64}
+		testInterpolation(t, x, append([]int64{-2, 2, math.MaxInt64 - 2}, x...))
+	})
+	t.Run("infinities", func(t *testing.T) {
+		x := []float64{math.Inf(-1), -1, 0, 1, 2, 3, math.Inf(1)}
+		testInterpolation(t, x, append([]float64{-0.5, 2.5, math.MaxFloat64}, x...))
+	})
+}
+
+func FuzzInterpolation(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s []byte, target byte) {
+		slices.Sort(s)
+
+		if i, want := Interpolation(s, target), LowerBound(s, target); i != want {
+			t.Errorf("Interpolation(%v, %d) = %d; want %d", s, target, i, want)
+		}
+	})
+}
+
+func BenchmarkInterpolation(b *testing.B) {
+	for _, n := range []int{1 << 10, 1 << 20, 1 << 24} {
+		x := make([]int, n)
+		for i := range x {
+			x[i] = rand.Intn(1 << 40)
+		}
+		slices.Sort(x)
+
+		b.Run(fmt.Sprintf("LowerBound/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				LowerBound(x, rand.Intn(1<<40))
+			}
+		})
+		b.Run(fmt.Sprintf("Interpolation/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Interpolation(x, rand.Intn(1<<40))
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package search
+
+// kmp implements the Knuth–Morris–Pratt algorithm.
+// It runs in O(n) time, where n is the length of the text, and compares each element of the text at most twice on average.
+type kmp[T comparable] struct {
+	pattern []T
+	// fail[j] is the length of the longest proper border (a prefix that is also a suffix) of pattern[:j].
+	fail []int
+}
+
+func newKMP[T comparable](pattern []T) *kmp[T] {
+	m := len(pattern)
+	fail := make([]int, m+1)
+	for i, k := 1, 0; i < m; i++ {
+		for k > 0 && pattern[i] != pattern[k] {
+			k = fail[k]
+		}
+		if pattern[i] == pattern[k] {
+			k++
+		}
+		fail[i+1] = k
+	}
+	return &kmp[T]{pattern: pattern, fail: fail}
+}
+
+func (p *kmp[T]) scan(text []T, overlap bool, yield func(i int) bool) {
+	m := len(p.pattern)
+	j := 0 // the length of the matched prefix of the pattern
+	for i, c := range text {
+		for j > 0 && p.pattern[j] != c {
+			j = p.fail[j]
+		}
+		if p.pattern[j] == c {
+			j++
+		}
+		if j == m {
+			if !yield(i - m + 1) {
+				return
+			}
+			if overlap {
+				j = p.fail[m]
+			} else {
+				j = 0
+			}
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package search
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"unsafe"
+)
+
+// PatternAlgorithm identifies a substring search algorithm used by [Pattern] and [StringPattern].
+type PatternAlgorithm int
+
+const (
+	KMPSearch        PatternAlgorithm = iota // Knuth–Morris–Pratt
+	BoyerMooreSearch                         // Boyer–Moore with the bad character and the good suffix rules
+	HorspoolSearch                           // Boyer–Moore–Horspool
+	TwoWaySearch                             // Crochemore–Perrin Two-Way
+)
+
+// PatternAlgorithms lists all the substring search algorithms of the package.
+var PatternAlgorithms = []PatternAlgorithm{
+	KMPSearch,
+	BoyerMooreSearch,
+	HorspoolSearch,
+	TwoWaySearch,
+}
+
+var patternAlgorithmNames = [...]string{
+	KMPSearch:        "KMP",
+	BoyerMooreSearch: "BoyerMoore",
+	HorspoolSearch:   "Horspool",
+	TwoWaySearch:     "TwoWay",
+}
+
+// String returns the name of the algorithm.
+func (a PatternAlgorithm) String() string {
+	if a < 0 || int(a) >= len(patternAlgorithmNames) {
+		return fmt.Sprintf("PatternAlgorithm(%d)", int(a))
+	}
+	return patternAlgorithmNames[a]
+}
+
+// matcher finds the occurrences of a non-empty pattern in texts.
+type matcher[T comparable] interface {
+	// scan calls yield with the indexes of the occurrences of the pattern in the text in increasing order until yield returns false.
+	// If overlap is false, it skips the occurrences overlapping the previous reported one.
+	scan(text []T, overlap bool, yield func(i int) bool)
+}
+
+/*
+Pattern is a precompiled pattern for searching in sequences of comparable elements, such as bytes or tokens.
+Unlike the functions of the strings and bytes packages, it searches in slices of any comparable type.
+The pattern is preprocessed once by [Compile], so it's cheap to search for it in many texts.
+A Pattern is safe for concurrent use by multiple goroutines.
+*/
+type Pattern[T comparable] struct {
+	pattern []T
+	alg     PatternAlgorithm
+	m       matcher[T]
+}
+
+/*
+Compile returns the pattern preprocessed for searching using the algorithm. It runs in O(m) time, where m is the length of the pattern,
+but the Boyer–Moore and Horspool algorithms use a map of the elements of the pattern unless they're of a one-byte type.
+It panics if the algorithm is unknown.
+*/
+func Compile[T comparable](alg PatternAlgorithm, pattern []T) *Pattern[T] {
+	pattern = slices.Clip(slices.Clone(pattern))
+	p := &Pattern[T]{pattern: pattern, alg: alg}
+	if len(pattern) == 0 {
+		if alg < 0 || int(alg) >= len(patternAlgorithmNames) {
+			panic(fmt.Sprintf("search: unknown algorithm %v", alg))
+		}
+		return p
+	}
+
+	switch alg {
+	case KMPSearch:
+		p.m = newKMP(pattern)
+	case BoyerMooreSearch:
+		p.m = newBoyerMoore(pattern)
+	case HorspoolSearch:
+		p.m = newHorspool(pattern)
+	case TwoWaySearch:
+		p.m = newTwoWay(pattern)
+	default:
+		panic(fmt.Sprintf("search: unknown algorithm %v", alg))
+	}
+	return p
+}
+
+// Len returns the length of the pattern.
+func (p *Pattern[T]) Len() int {
+	return len(p.pattern)
+}
+
+// Algorithm returns the algorithm used to search for the pattern.
+func (p *Pattern[T]) Algorithm() PatternAlgorithm {
+	return p.alg
+}
+
+// Index returns the index of the first occurrence of the pattern in the text, or -1 if the pattern is not present.
+// The empty pattern occurs at index 0.
+func (p *Pattern[T]) Index(text []T) int {
+	if len(p.pattern) == 0 {
+		return 0
+	}
+
+	ind := -1
+	p.m.scan(text, false, func(i int) bool {
+		ind = i
+		return false
+	})
+	return ind
+}
+
+// LastIndex returns the index of the last occurrence of the pattern in the text, or -1 if the pattern is not present.
+// The empty pattern occurs at index len(text).
+// It scans the whole text, so it's not faster than [Pattern.Index] if the last occurrence is near the end of the text.
+func (p *Pattern[T]) LastIndex(text []T) int {
+	if len(p.pattern) == 0 {
+		return len(text)
+	}
+
+	ind := -1
+	p.m.scan(text, true, func(i int) bool {
+		ind = i
+		return true
+	})
+	return ind
+}
+
+// IndexAll returns the indexes of all non-overlapping occurrences of the pattern in the text in increasing order.
+// The empty pattern occurs at every index from 0 to len(text).
+func (p *Pattern[T]) IndexAll(text []T) []int {
+	var inds []int
+	if len(p.pattern) == 0 {
+		for i := 0; i <= len(text); i++ {
+			inds = append(inds, i)
+		}
+		return inds
+	}
+
+	p.m.scan(text, false, func(i int) bool {
+		inds = append(inds, i)
+		return true
+	})
+	return inds
+}
+
+// Count returns the number of non-overlapping occurrences of the pattern in the text.
+// The empty pattern occurs len(text)+1 times.
+func (p *Pattern[T]) Count(text []T) int {
+	if len(p.pattern) == 0 {
+		return len(text) + 1
+	}
+
+	n := 0
+	p.m.scan(text, false, func(int) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+/*
+StringPattern is a precompiled pattern for searching in strings.
+It's the same as [Pattern] of bytes, but searches in strings without copying them.
+Unlike the functions of the strings package, the empty pattern occurs at every byte index rather than at every rune boundary.
+*/
+type StringPattern struct {
+	p *Pattern[byte]
+}
+
+// CompileString returns the pattern preprocessed for searching in strings using the algorithm, as described in [Compile].
+func CompileString(alg PatternAlgorithm, pattern string) *StringPattern {
+	return &StringPattern{Compile(alg, []byte(pattern))}
+}
+
+// Len returns the length of the pattern in bytes.
+func (p *StringPattern) Len() int {
+	return p.p.Len()
+}
+
+// Algorithm returns the algorithm used to search for the pattern.
+func (p *StringPattern) Algorithm() PatternAlgorithm {
+	return p.p.Algorithm()
+}
+
+// Index is the same as [Pattern.Index], but searches in a string.
+func (p *StringPattern) Index(s string) int {
+	return p.p.Index(bytesOf(s))
+}
+
+// LastIndex is the same as [Pattern.LastIndex], but searches in a string.
+func (p *StringPattern) LastIndex(s string) int {
+	return p.p.LastIndex(bytesOf(s))
+}
+
+// IndexAll is the same as [Pattern.IndexAll], but searches in a string.
+func (p *StringPattern) IndexAll(s string) []int {
+	return p.p.IndexAll(bytesOf(s))
+}
+
+// Count is the same as [Pattern.Count], but searches in a string.
+func (p *StringPattern) Count(s string) int {
+	return p.p.Count(bytesOf(s))
+}
+
+// bytesOf returns the bytes of the string without copying them. The bytes must not be modified.
+func bytesOf(s string) []byte {
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
+
+// shiftTable maps the elements to shifts of a pattern, and the elements not in the table to the default shift.
+// The elements of one-byte types are mapped using an array rather than a map.
+type shiftTable[T comparable] struct {
+	dense  *[256]int
+	sparse map[T]int
+	def    int
+}
+
+// newShiftTable returns an empty shift table with the default shift def.
+func newShiftTable[T comparable](def int) *shiftTable[T] {
+	t := &shiftTable[T]{def: def}
+	// Only the values of these types are equal if and only if their bytes are equal.
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Uint8, reflect.Int8, reflect.Bool:
+		t.dense = new([256]int)
+		for i := range t.dense {
+			t.dense[i] = def
+		}
+	default:
+		t.sparse = make(map[T]int)
+	}
+	return t
+}
+
+// set maps the element to the shift.
+func (t *shiftTable[T]) set(c T, shift int) {
+	if t.dense != nil {
+		t.dense[*(*uint8)(unsafe.Pointer(&c))] = shift
+	} else {
+		t.sparse[c] = shift
+	}
+}
+
+// get returns the shift of the element.
+func (t *shiftTable[T]) get(c T) int {
+	if t.dense != nil {
+		return t.dense[*(*uint8)(unsafe.Pointer(&c))]
+	}
+	if shift, ok := t.sparse[c]; ok {
+		return shift
+	}
+	return t.def
+}
+
+// badCharacterTable returns the bad character table of the pattern: the distance from the last occurrence of each element
+// in the pattern without its last element to the end of the pattern, or the length of the pattern if there is no such occurrence.
+func badCharacterTable[T comparable](pattern []T) *shiftTable[T] {
+	m := len(pattern)
+	t := newShiftTable[T](m)
+	for i, c := range pattern[:m-1] {
+		t.set(c, m-1-i)
+	}
+	return t
+}
@@ -0,0 +1,255 @@
+package search
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// naiveIndexAll returns the indexes of all occurrences of the pattern in the text, overlapping if overlap is true.
+func naiveIndexAll[T comparable](text, pattern []T, overlap bool) []int {
+	var inds []int
+	for i := 0; i+len(pattern) <= len(text); i++ {
+		if slices.Equal(text[i:i+len(pattern)], pattern) {
+			inds = append(inds, i)
+			if !overlap && len(pattern) > 0 {
+				i += len(pattern) - 1
+			}
+		}
+	}
+	return inds
+}
+
+// testPattern checks all methods of the patterns compiled using all algorithms against the naive search.
+func testPattern[T comparable](t *testing.T, text, pattern []T) {
+	t.Helper()
+
+	all := naiveIndexAll(text, pattern, false)
+	overlapping := naiveIndexAll(text, pattern, true)
+	index, lastIndex := -1, -1
+	if len(all) > 0 {
+		index, lastIndex = all[0], overlapping[len(overlapping)-1]
+	}
+
+	for _, alg := range PatternAlgorithms {
+		p := Compile(alg, pattern)
+
+		if i := p.Index(text); i != index {
+			t.Fatalf("%v: Index(%v, %v) = %d; want %d", alg, text, pattern, i, index)
+		}
+		if i := p.LastIndex(text); i != lastIndex {
+			t.Fatalf("%v: LastIndex(%v, %v) = %d; want %d", alg, text, pattern, i, lastIndex)
+		}
+		if inds := p.IndexAll(text); !slices.Equal(inds, all) {
+			t.Fatalf("%v: IndexAll(%v, %v) = %v; want %v", alg, text, pattern, inds, all)
+		}
+		if n := p.Count(text); n != len(all) {
+			t.Fatalf("%v: Count(%v, %v) = %d; want %d", alg, text, pattern, n, len(all))
+		}
+	}
+}
+
+// randomText returns a random slice of length n over the first k letters.
+func randomText(n, k int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('a' + rand.Intn(k))
+	}
+	return b
+}
+
+func TestPattern(t *testing.T) {
+	tests := map[string]struct {
+		text, pattern string
+	}{
+		"empty":            {"", ""},
+		"empty pattern":    {"abc", ""},
+		"empty text":       {"", "a"},
+		"longer pattern":   {"ab", "abc"},
+		"equal":            {"abc", "abc"},
+		"prefix":           {"abcd", "ab"},
+		"suffix":           {"abcd", "cd"},
+		"absent":           {"abcd", "ac"},
+		"overlapping":      {"aaaaa", "aa"},
+		"periodic":         {"abababab", "abab"},
+		"periodic; broken": {"abababcabababab", "ababab"},
+		"mississippi":      {"mississippi", "issi"},
+		"good suffix":      {"xxabcxxbcxxabcbc", "abcxxbc"},
+		"critical":         {"aabaabaabbaabaab", "aabaab"},
+		"two-way":          {"GCATCGCAGAGAGTATACAGTACG", "GCAGAGAG"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			testPattern(t, []byte(tt.text), []byte(tt.pattern))
+		})
+	}
+}
+
+func TestPattern_Random(t *testing.T) {
+	for _, k := range []int{1, 2, 3, 26} {
+		t.Run(fmt.Sprint(k), func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				text := randomText(rand.Intn(100), k)
+				var pattern []byte
+				if len(text) > 0 && rand.Intn(2) == 0 {
+					// A substring of the text, so it's present.
+					l := rand.Intn(len(text))
+					pattern = text[l : l+1+rand.Intn(min(10, len(text)-l))]
+				} else {
+					pattern = randomText(1+rand.Intn(8), k)
+				}
+				testPattern(t, text, pattern)
+			}
+		})
+	}
+}
+
+func TestPattern_Periodic(t *testing.T) {
+	// Periodic patterns and texts are the worst cases of the algorithms.
+	for _, period := range []string{"a", "ab", "aab", "abaab", "abcab"} {
+		for r := 1; r <= 5; r++ {
+			pattern := strings.Repeat(period, r)
+			for _, text := range []string{
+				strings.Repeat(period, 10),
+				strings.Repeat(period, 10) + pattern[:len(pattern)-1],
+				strings.Repeat(pattern[:len(pattern)-1]+"c", 5),
+			} {
+				testPattern(t, []byte(text), []byte(pattern))
+				testPattern(t, []byte(text), []byte(pattern[1:]))
+			}
+		}
+	}
+}
+
+func TestPattern_Tokens(t *testing.T) {
+	text := strings.Fields("the quick brown fox jumps over the lazy dog and the quick cat jumps over the quick brown fox")
+	for _, pattern := range []string{"the quick", "the quick brown fox", "jumps over the", "fox", "the lazy cat", ""} {
+		testPattern(t, text, strings.Fields(pattern))
+	}
+
+	ints := []int{1, 2, 3, 1000, 1, 2, 3, 1, 2, 3, 1000}
+	testPattern(t, ints, []int{1, 2, 3, 1000})
+	testPattern(t, ints, []int{3, 1, 2})
+
+	bools := []bool{true, false, false, true, false, false, true}
+	testPattern(t, bools, []bool{false, true})
+	testPattern(t, bools, []bool{true, false, false, true})
+}
+
+func TestPattern_Mutation(t *testing.T) {
+	pattern := []byte("abc")
+	p := Compile(KMPSearch, pattern)
+	pattern[0] = 'x'
+
+	if i := p.Index([]byte("xxabc")); i != 2 {
+		t.Errorf("Index after modifying the pattern = %d; want 2", i)
+	}
+}
+
+func TestCompile_Unknown(t *testing.T) {
+	for _, pattern := range []string{"", "a"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Compile(%q) didn't panic on an unknown algorithm", pattern)
+				}
+			}()
+			Compile(PatternAlgorithm(-1), []byte(pattern))
+		}()
+	}
+}
+
+func TestPatternAlgorithm_String(t *testing.T) {
+	if s := TwoWaySearch.String(); s != "TwoWay" {
+		t.Errorf("TwoWaySearch.String() = %q; want %q", s, "TwoWay")
+	}
+	if s := PatternAlgorithm(7).String(); s != "PatternAlgorithm(7)" {
+		t.Errorf("PatternAlgorithm(7).String() = %q; want %q", s, "PatternAlgorithm(7)")
+	}
+}
+
+func TestStringPattern(t *testing.T) {
+	text := "a rose is a rose is a rose"
+	for _, alg := range PatternAlgorithms {
+		p := CompileString(alg, "rose is")
+
+		if p.Len() != 7 || p.Algorithm() != alg {
+			t.Errorf("%v: Len() = %d, Algorithm() = %v", alg, p.Len(), p.Algorithm())
+		}
+		if i, want := p.Index(text), strings.Index(text, "rose is"); i != want {
+			t.Errorf("%v: Index = %d; want %d", alg, i, want)
+		}
+		if i, want := p.LastIndex(text), strings.LastIndex(text, "rose is"); i != want {
+			t.Errorf("%v: LastIndex = %d; want %d", alg, i, want)
+		}
+		if n, want := p.Count(text), strings.Count(text, "rose is"); n != want {
+			t.Errorf("%v: Count = %d; want %d", alg, n, want)
+		}
+		if inds := p.IndexAll(text); !slices.Equal(inds, []int{2, 12}) {
+			t.Errorf("%v: IndexAll = %v; want [2 12]", alg, inds)
+		}
+		if i := p.Index(""); i != -1 {
+			t.Errorf("%v: Index(\"\") = %d; want -1", alg, i)
+		}
+	}
+}
+
+func FuzzStringPattern(f *testing.F) {
+	f.Add("abababcabababab", "ababab")
+	f.Fuzz(func(t *testing.T, text, pattern string) {
+		if pattern == "" {
+			return
+		}
+		for _, alg := range PatternAlgorithms {
+			p := CompileString(alg, pattern)
+
+			if i, want := p.Index(text), strings.Index(text, pattern); i != want {
+				t.Errorf("%v: Index(%q, %q) = %d; want %d", alg, text, pattern, i, want)
+			}
+			if i, want := p.LastIndex(text), strings.LastIndex(text, pattern); i != want {
+				t.Errorf("%v: LastIndex(%q, %q) = %d; want %d", alg, text, pattern, i, want)
+			}
+			if n, want := p.Count(text), strings.Count(text, pattern); n != want {
+				t.Errorf("%v: Count(%q, %q) = %d; want %d", alg, text, pattern, n, want)
+			}
+		}
+	})
+}
+
+func BenchmarkPattern(b *testing.B) {
+	n := 1 << 20
+	texts := map[string][]byte{
+		"random":   randomText(n, 26),
+		"binary":   randomText(n, 2),
+		"periodic": []byte(strings.Repeat("a", n)),
+	}
+	patterns := map[string][]byte{
+		"random":   []byte("qwertyuiopasdfgh"),
+		"binary":   append(randomText(31, 2), 'c'),
+		"periodic": []byte(strings.Repeat("a", 31) + "b"),
+	}
+
+	for _, name := range []string{"random", "binary", "periodic"} {
+		text, pattern := texts[name], patterns[name]
+		s, sub := string(text), string(pattern)
+
+		b.Run(fmt.Sprintf("%s/strings.Index", name), func(b *testing.B) {
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				strings.Index(s, sub)
+			}
+		})
+		for _, alg := range PatternAlgorithms {
+			p := Compile(alg, pattern)
+			b.Run(fmt.Sprintf("%s/%v", name, alg), func(b *testing.B) {
+				b.SetBytes(int64(n))
+				for i := 0; i < b.N; i++ {
+					p.Index(text)
+				}
+			})
+		}
+	}
+}
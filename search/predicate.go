@@ -0,0 +1,98 @@
+package search
+
+/*
+PredicateInt implements binary search on the integers of the interval [lo, hi). It returns the smallest integer i in the interval at which p(i) is true,
+assuming that p(i) == true implies p(i+1) == true.
+That is, PredicateInt requires that p is false for some (possibly empty) prefix of the interval and then true for the (possibly empty) remainder.
+If there is no such integer i, PredicateInt returns hi.
+It calls p O(log(hi-lo)) times and never overflows, so the interval may span the whole range of int64.
+It's useful for searching on the answer: for example, finding the smallest n such that n machines handle the load.
+*/
+func PredicateInt(lo, hi int64, p func(int64) bool) int64 {
+	for lo < hi {
+		// hi-lo may overflow int64, but not uint64.
+		mid := lo + int64(uint64(hi-lo)>>1)
+
+		if p(mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	return lo
+}
+
+/*
+PredicateFloat implements binary search on the real numbers of the interval [lo, hi]. It returns the approximation of the smallest number x in the interval at which p(x) is true,
+assuming that p(x) == true implies p(y) == true for any y > x.
+The search ends when the length of the interval containing x is at most eps, or when it can't be halved in float64 precision,
+so eps = 0 finds x to the full float64 precision.
+The returned number is the upper end of the final interval, so p is true at it, unless it's hi at which p was never evaluated.
+If p is false for all numbers in the interval, PredicateFloat returns hi.
+*/
+func PredicateFloat(lo, hi float64, p func(float64) bool, eps float64) float64 {
+	for hi-lo > eps {
+		mid, ok := midpoint(lo, hi)
+		if !ok {
+			break
+		}
+
+		if p(mid) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	return hi
+}
+
+/*
+PredicateFloatN is the same as [PredicateFloat], but it halves the interval exactly n times, unless it can't be halved in float64 precision.
+It calls p at most n times, regardless of the length of the interval, which makes its running time predictable.
+*/
+func PredicateFloatN(lo, hi float64, p func(float64) bool, n int) float64 {
+	for ; n > 0; n-- {
+		mid, ok := midpoint(lo, hi)
+		if !ok {
+			break
+		}
+
+		if p(mid) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	return hi
+}
+
+/*
+Bisection implements the bisection method of finding a root of the monotone function f on the interval [lo, hi].
+It returns the approximation of the number x at which f changes sign, as described in [PredicateFloat], and true.
+If f has the same nonzero sign at both ends of the interval, it returns false.
+The function f may be either increasing or decreasing.
+*/
+func Bisection(lo, hi float64, f func(float64) float64, eps float64) (float64, bool) {
+	flo, fhi := f(lo), f(hi)
+	switch {
+	case flo == 0:
+		return lo, true
+	case fhi == 0:
+		return hi, true
+	case flo < 0 && fhi > 0:
+		return PredicateFloat(lo, hi, func(x float64) bool { return f(x) >= 0 }, eps), true
+	case flo > 0 && fhi < 0:
+		return PredicateFloat(lo, hi, func(x float64) bool { return f(x) <= 0 }, eps), true
+	default:
+		return 0, false
+	}
+}
+
+// midpoint returns the midpoint of the interval [lo, hi] without overflow, and false if it isn't strictly inside the interval.
+func midpoint(lo, hi float64) (float64, bool) {
+	mid := lo + (hi/2 - lo/2)
+	return mid, lo < mid && mid < hi
+}
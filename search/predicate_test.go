@@ -0,0 +1,117 @@
+package search
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPredicateInt(t *testing.T) {
+	p := func(x int64) func(int64) bool {
+		return func(v int64) bool {
+			return v >= x
+		}
+	}
+
+	tests := map[string]struct {
+		lo, hi int64
+		f      func(int64) bool
+		ind    int64
+	}{
+		"[0, 0); true":             {0, 0, p(math.MinInt64), 0},
+		"[0, 1); >=1":              {0, 1, p(1), 1},
+		"[0, 1); true":             {0, 1, p(math.MinInt64), 0},
+		"[0, 100); >=91":           {0, 100, p(91), 91},
+		"[0, 100); false":          {0, 100, p(math.MaxInt64), 100},
+		"[-100, 100); >=-7":        {-100, 100, p(-7), -7},
+		"[min, max); >=0":          {math.MinInt64, math.MaxInt64, p(0), 0},
+		"[min, max); >=min":        {math.MinInt64, math.MaxInt64, p(math.MinInt64), math.MinInt64},
+		"[min, max); >=max-1":      {math.MinInt64, math.MaxInt64, p(math.MaxInt64 - 1), math.MaxInt64 - 1},
+		"[min, max); false":        {math.MinInt64, math.MaxInt64, p(math.MaxInt64), math.MaxInt64},
+		"[max-1, max); >=max-1":    {math.MaxInt64 - 1, math.MaxInt64, p(math.MaxInt64 - 1), math.MaxInt64 - 1},
+		"[1, 1e18); n*n>=10^18-1":  {1, 1e18, func(n int64) bool { return n >= 1e9 || n*n >= 1e18-1 }, 1e9},
+		"[1, 1e6); 1000*n>=123457": {1, 1e6, func(n int64) bool { return 1000*n >= 123457 }, 124},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if ind := PredicateInt(tt.lo, tt.hi, tt.f); ind != tt.ind {
+				t.Errorf("got %d; want %d", ind, tt.ind)
+			}
+		})
+	}
+}
+
+func TestPredicateFloat(t *testing.T) {
+	sqrt2 := func(x float64) bool { return x*x >= 2 }
+
+	for _, eps := range []float64{1, 1e-3, 1e-9, 0} {
+		x := PredicateFloat(0, 2, sqrt2, eps)
+		if x < math.Sqrt2 || x-math.Sqrt2 > eps {
+			t.Errorf("PredicateFloat(0, 2, x*x >= 2, %g) = %g; want within %g above %g", eps, x, eps, math.Sqrt2)
+		}
+	}
+
+	if x := PredicateFloat(0, 2, sqrt2, 0); math.Nextafter(x, 0)*math.Nextafter(x, 0) >= 2 {
+		t.Errorf("PredicateFloat(0, 2, x*x >= 2, 0) = %g; want the smallest float64", x)
+	}
+	if x := PredicateFloat(-math.MaxFloat64, math.MaxFloat64, func(x float64) bool { return x >= 1e300 }, 0); x != 1e300 {
+		t.Errorf("PredicateFloat(-max, max, x >= 1e300, 0) = %g; want %g", x, 1e300)
+	}
+	if x := PredicateFloat(0, 1, func(float64) bool { return false }, 1e-6); x != 1 {
+		t.Errorf("PredicateFloat(0, 1, false, 1e-6) = %g; want 1", x)
+	}
+	if x := PredicateFloat(0, 1, func(float64) bool { return true }, 1e-6); x > 1e-6 {
+		t.Errorf("PredicateFloat(0, 1, true, 1e-6) = %g; want at most 1e-6", x)
+	}
+	if x := PredicateFloat(1, 1, sqrt2, 0); x != 1 {
+		t.Errorf("PredicateFloat(1, 1, x*x >= 2, 0) = %g; want 1", x)
+	}
+}
+
+func TestPredicateFloatN(t *testing.T) {
+	var calls int
+	sqrt2 := func(x float64) bool {
+		calls++
+		return x*x >= 2
+	}
+
+	for _, n := range []int{0, 1, 10, 50, 1000} {
+		calls = 0
+		x := PredicateFloatN(0, 2, sqrt2, n)
+
+		if calls > n {
+			t.Errorf("PredicateFloatN(0, 2, x*x >= 2, %d) called p %d times", n, calls)
+		}
+		if eps := math.Ldexp(2, -n); x < math.Sqrt2 || x-math.Sqrt2 > eps {
+			t.Errorf("PredicateFloatN(0, 2, x*x >= 2, %d) = %g; want within %g above %g", n, x, eps, math.Sqrt2)
+		}
+	}
+}
+
+func TestBisection(t *testing.T) {
+	tests := map[string]struct {
+		lo, hi float64
+		f      func(float64) float64
+		root   float64
+		ok     bool
+	}{
+		"x^3-8":            {-10, 10, func(x float64) float64 { return x*x*x - 8 }, 2, true},
+		"cos":              {0, 3, math.Cos, math.Pi / 2, true},
+		"decreasing":       {-10, 10, func(x float64) float64 { return 3 - x }, 3, true},
+		"root at lo":       {3, 10, func(x float64) float64 { return x - 3 }, 3, true},
+		"root at hi":       {-10, 3, func(x float64) float64 { return x - 3 }, 3, true},
+		"no root":          {4, 10, func(x float64) float64 { return x - 3 }, 0, false},
+		"no root; negated": {4, 10, func(x float64) float64 { return 3 - x }, 0, false},
+	}
+
+	eps := 1e-9
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			root, ok := Bisection(tt.lo, tt.hi, tt.f, eps)
+
+			if ok != tt.ok || math.Abs(root-tt.root) > eps {
+				t.Errorf("got %g, %t; want %g, %t", root, ok, tt.root, tt.ok)
+			}
+		})
+	}
+}
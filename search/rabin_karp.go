@@ -0,0 +1,207 @@
+package search
+
+import (
+	"bytes"
+	"cmp"
+	"math/rand/v2"
+	"slices"
+)
+
+/*
+IndexRabinKarp implements the Rabin–Karp algorithm. It returns the index of the first occurrence of the pattern in the text,
+or -1 if the pattern is not present. The empty pattern occurs at index 0.
+It compares the [PolynomialHash] of the pattern with the hashes of the windows of the text, and the bytes only if the hashes are equal,
+so it runs in O(n+m) expected time, where n and m are the lengths of the text and the pattern.
+The base of the hash is chosen at random on each call, so the expected time holds for any input, even one crafted to make the hashes collide.
+*/
+func IndexRabinKarp(text, pattern []byte) int {
+	n, m := len(text), len(pattern)
+	if m == 0 {
+		return 0
+	}
+	if m > n {
+		return -1
+	}
+
+	h := NewPolynomialHash(m, randomPolynomialBase())
+	h.Write(pattern)
+	want := h.Sum64()
+
+	h.Reset()
+	h.Write(text[:m])
+	for i := 0; ; i++ {
+		if h.Sum64() == want && bytes.Equal(text[i:i+m], pattern) {
+			return i
+		}
+		if i+m == n {
+			return -1
+		}
+		h.Roll(text[i], text[i+m])
+	}
+}
+
+/*
+RabinKarp implements the Rabin–Karp algorithm for many patterns at once. The hashes of the patterns of each length are stored in a map,
+and a [PolynomialHash] of each length is slid over the text, so it finds all occurrences of the patterns in a text of length n
+in O(n*l+k) expected time, where l is the number of the distinct lengths of the patterns and k is the number of the occurrences.
+The base of the hashes is chosen at random for each matcher, so the expected time holds for any text and patterns.
+It's slower than [AhoCorasick], but uses much less memory for many long patterns of a few lengths, such as chunks of data,
+since it stores a hash per pattern rather than a trie of all their bytes.
+The empty patterns never match.
+A RabinKarp is safe for concurrent use by multiple goroutines.
+*/
+type RabinKarp struct {
+	patterns [][]byte
+	groups   []rabinKarpGroup // by decreasing length
+	base     uint64           // the base of the hashes
+}
+
+// rabinKarpGroup holds the hashes of the patterns of the same length.
+type rabinKarpGroup struct {
+	length int
+	// hashes maps the hashes to the indexes of the patterns with them in increasing order.
+	hashes map[uint64][]int
+}
+
+// NewRabinKarp returns the Rabin–Karp matcher of the patterns. It runs in O(m) time, where m is the total length of the patterns.
+func NewRabinKarp(patterns []string) *RabinKarp {
+	rk := &RabinKarp{patterns: make([][]byte, len(patterns)), base: randomPolynomialBase()}
+	groups := make(map[int]map[uint64][]int)
+	for i, p := range patterns {
+		rk.patterns[i] = []byte(p)
+		if p == "" {
+			continue
+		}
+
+		g, ok := groups[len(p)]
+		if !ok {
+			g = make(map[uint64][]int)
+			groups[len(p)] = g
+		}
+		h := NewPolynomialHash(len(p), rk.base)
+		h.Write(rk.patterns[i])
+		g[h.Sum64()] = append(g[h.Sum64()], i)
+	}
+
+	for length, hashes := range groups {
+		rk.groups = append(rk.groups, rabinKarpGroup{length: length, hashes: hashes})
+	}
+	slices.SortFunc(rk.groups, func(a, b rabinKarpGroup) int {
+		return cmp.Compare(b.length, a.length)
+	})
+	return rk
+}
+
+// Len returns the number of the patterns.
+func (rk *RabinKarp) Len() int {
+	return len(rk.patterns)
+}
+
+// Find calls fn with all matches of the patterns in the text, including the overlapping ones, until fn returns false.
+// The matches are reported in the order of their ends, and the matches ending at the same offset from the longest one to the shortest one.
+func (rk *RabinKarp) Find(text []byte, fn func(m Match) bool) {
+	hs := make([]*PolynomialHash, len(rk.groups))
+	for i, g := range rk.groups {
+		hs[i] = NewPolynomialHash(g.length, rk.base)
+	}
+
+	for end := 1; end <= len(text); end++ {
+		for i, g := range rk.groups {
+			h, start := hs[i], end-g.length
+			if start <= 0 {
+				h.Write(text[end-1 : end])
+			} else {
+				h.Roll(text[start-1], text[end-1])
+			}
+			if start < 0 {
+				continue
+			}
+
+			for _, p := range g.hashes[h.Sum64()] {
+				if bytes.Equal(text[start:end], rk.patterns[p]) && !fn(Match{Pattern: p, Start: start, End: end}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FindString is the same as [RabinKarp.Find], but searches in a string.
+func (rk *RabinKarp) FindString(text string, fn func(m Match) bool) {
+	rk.Find(bytesOf(text), fn)
+}
+
+// FindAll returns all matches of the patterns in the text in the order described in [RabinKarp.Find].
+func (rk *RabinKarp) FindAll(text []byte) []Match {
+	var ms []Match
+	rk.Find(text, func(m Match) bool {
+		ms = append(ms, m)
+		return true
+	})
+	return ms
+}
+
+/*
+LongestRepeatedSubstring returns the longest substring occurring in the text at least twice, possibly overlapping,
+as the indexes i < j of its first two occurrences and its length n, so text[i:i+n] == text[j:j+n].
+If there are several such substrings, it returns the one whose second occurrence is the leftmost.
+If no byte of the text is repeated, it returns n = 0.
+It binary searches for the length using [PredicateInt], checking whether a substring of a length is repeated
+by storing the hashes of all substrings of the length in a map, so it runs in O(n*log(n)) expected time and uses O(n) space.
+The base of the hashes is chosen at random on each call, so the expected time holds for any text.
+For repeated queries on the same text, [SuffixIndex.LongestRepeatedSubstring] returns the same result in O(n) time.
+*/
+func LongestRepeatedSubstring(text []byte) (i, j, n int) {
+	// The substrings of the length that is not repeated are not repeated either for any longer length.
+	base := randomPolynomialBase()
+	n = int(PredicateInt(1, int64(len(text)), func(l int64) bool {
+		_, _, ok := repeatedSubstring(text, int(l), base)
+		return !ok
+	})) - 1
+	if n <= 0 {
+		return 0, 0, 0
+	}
+
+	i, j, _ = repeatedSubstring(text, n, base)
+	return i, j, n
+}
+
+// repeatedSubstring returns the indexes of the first two occurrences of the first repeated substring of the text of length l,
+// and whether there is such a substring, using the hashes with the base.
+func repeatedSubstring(text []byte, l int, base uint64) (i, j int, ok bool) {
+	h := NewPolynomialHash(l, base)
+	h.Write(text[:l])
+	// first maps the hashes to the first substrings with them, and rest to the other different substrings with them,
+	// which are very unlikely, so a slice isn't allocated for each hash.
+	first := make(map[uint64]int, len(text)-l+1)
+	var rest map[uint64][]int
+	for j := 0; ; j++ {
+		s := h.Sum64()
+		if i, ok := first[s]; !ok {
+			first[s] = j
+		} else if bytes.Equal(text[i:i+l], text[j:j+l]) {
+			return i, j, true
+		} else {
+			if rest == nil {
+				rest = make(map[uint64][]int)
+			}
+			for _, i := range rest[s] {
+				if bytes.Equal(text[i:i+l], text[j:j+l]) {
+					return i, j, true
+				}
+			}
+			rest[s] = append(rest[s], j)
+		}
+
+		if j+l == len(text) {
+			return 0, 0, false
+		}
+		h.Roll(text[j], text[j+l])
+	}
+}
+
+// randomPolynomialBase returns a random base of [PolynomialHash] in the interval [2, 2^61-2],
+// for which the hashes of different windows of length n are equal with probability at most n/2^61 for any input.
+func randomPolynomialBase() uint64 {
+	return 2 + rand.Uint64N(mersenne61-3)
+}
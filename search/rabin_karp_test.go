@@ -0,0 +1,250 @@
+package search
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// naiveLongestRepeatedSubstring returns the length of the longest repeated substring of the text.
+func naiveLongestRepeatedSubstring(text []byte) int {
+	best := 0
+	for i := range text {
+		for j := i + 1; j < len(text); j++ {
+			n := 0
+			for j+n < len(text) && text[i+n] == text[j+n] {
+				n++
+			}
+			best = max(best, n)
+		}
+	}
+	return best
+}
+
+func TestIndexRabinKarp(t *testing.T) {
+	tests := map[string]struct {
+		text, pattern string
+		want          int
+	}{
+		"empty":          {"", "", 0},
+		"empty pattern":  {"abc", "", 0},
+		"empty text":     {"", "a", -1},
+		"longer pattern": {"ab", "abc", -1},
+		"equal":          {"abc", "abc", 0},
+		"first":          {"abcabc", "bc", 1},
+		"last":           {"aaaaab", "ab", 4},
+		"absent":         {"aaaaaa", "ab", -1},
+		"leading zero":   {"\x00\x01\x01", "\x01\x01", 1},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if i := IndexRabinKarp([]byte(tt.text), []byte(tt.pattern)); i != tt.want {
+				t.Errorf("IndexRabinKarp(%q, %q) = %d; want %d", tt.text, tt.pattern, i, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexRabinKarp_Random(t *testing.T) {
+	for _, k := range []int{2, 3, 26} {
+		t.Run(fmt.Sprint(k), func(t *testing.T) {
+			for i := 0; i < 500; i++ {
+				text, pattern := randomText(rand.Intn(200), k), randomText(rand.Intn(8), k)
+				if i, want := IndexRabinKarp(text, pattern), bytes.Index(text, pattern); i != want {
+					t.Errorf("IndexRabinKarp(%q, %q) = %d; want %d", text, pattern, i, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRabinKarp(t *testing.T) {
+	tests := map[string]struct {
+		text     string
+		patterns []string
+	}{
+		"empty":          {"", []string{"a"}},
+		"no patterns":    {"abc", nil},
+		"classic":        {"ushers", []string{"he", "she", "his", "hers"}},
+		"empty pattern":  {"abc", []string{"", "b"}},
+		"duplicates":     {"abab", []string{"ab", "b", "ab"}},
+		"nested":         {"aaaa", []string{"a", "aa", "aaa"}},
+		"longer pattern": {"ab", []string{"abc"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rk := NewRabinKarp(tt.patterns)
+			if n := rk.Len(); n != len(tt.patterns) {
+				t.Errorf("Len() = %d; want %d", n, len(tt.patterns))
+			}
+			want := naiveMatches(tt.text, tt.patterns, OverlappingMatch, false)
+			if ms := rk.FindAll([]byte(tt.text)); !slices.Equal(ms, want) {
+				t.Errorf("FindAll(%q) = %v; want %v", tt.text, ms, want)
+			}
+		})
+	}
+}
+
+func TestRabinKarp_Random(t *testing.T) {
+	for _, k := range []int{2, 3, 26} {
+		t.Run(fmt.Sprint(k), func(t *testing.T) {
+			for i := 0; i < 200; i++ {
+				text := string(randomText(rand.Intn(200), k))
+				patterns := make([]string, rand.Intn(20))
+				for j := range patterns {
+					patterns[j] = string(randomText(rand.Intn(8), k))
+				}
+
+				rk := NewRabinKarp(patterns)
+				want := naiveMatches(text, patterns, OverlappingMatch, false)
+				var ms []Match
+				rk.FindString(text, func(m Match) bool {
+					ms = append(ms, m)
+					return true
+				})
+				if !slices.Equal(ms, want) {
+					t.Errorf("FindString(%q, %q) = %v; want %v", text, patterns, ms, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRabinKarp_Stop(t *testing.T) {
+	rk := NewRabinKarp([]string{"a", "aa"})
+	var ms []Match
+	rk.FindString(strings.Repeat("a", 100), func(m Match) bool {
+		ms = append(ms, m)
+		return len(ms) < 3
+	})
+	if len(ms) != 3 {
+		t.Errorf("FindString reported %d matches; want 3 before stopping", len(ms))
+	}
+}
+
+func TestRabinKarp_RandomBase(t *testing.T) {
+	// The bases of two matchers are equal with probability 2^-61.
+	if a, b := NewRabinKarp(nil).base, NewRabinKarp(nil).base; a == b {
+		t.Errorf("two matchers have the same base %#x", a)
+	}
+	for i := 0; i < 1000; i++ {
+		if base := randomPolynomialBase(); base < 2 || base > mersenne61-2 {
+			t.Fatalf("randomPolynomialBase() = %#x; want in [2, 2^61-2]", base)
+		}
+	}
+}
+
+func TestLongestRepeatedSubstring(t *testing.T) {
+	tests := map[string]struct {
+		text string
+		i, j int
+		n    int
+	}{
+		"empty":       {"", 0, 0, 0},
+		"single":      {"a", 0, 0, 0},
+		"distinct":    {"abcdef", 0, 0, 0},
+		"pair":        {"aa", 0, 1, 1},
+		"overlapping": {"aaaa", 0, 1, 3},
+		"banana":      {"banana", 1, 3, 3},
+		"mississippi": {"mississippi", 1, 4, 4},
+		"separate":    {"xabcyabcz", 1, 5, 3},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if i, j, n := LongestRepeatedSubstring([]byte(tt.text)); i != tt.i || j != tt.j || n != tt.n {
+				t.Errorf("LongestRepeatedSubstring(%q) = %d, %d, %d; want %d, %d, %d", tt.text, i, j, n, tt.i, tt.j, tt.n)
+			}
+		})
+	}
+}
+
+func testLongestRepeatedSubstring(t *testing.T, text []byte) {
+	t.Helper()
+	i, j, n := LongestRepeatedSubstring(text)
+	if want := naiveLongestRepeatedSubstring(text); n != want {
+		t.Fatalf("LongestRepeatedSubstring(%q) = %d, %d, %d; want the length %d", text, i, j, n, want)
+	}
+	if n > 0 && (i < 0 || i >= j || j+n > len(text) || !bytes.Equal(text[i:i+n], text[j:j+n])) {
+		t.Fatalf("LongestRepeatedSubstring(%q) = %d, %d, %d; not a repeated substring", text, i, j, n)
+	}
+}
+
+func TestLongestRepeatedSubstring_Random(t *testing.T) {
+	for _, k := range []int{2, 3, 26} {
+		t.Run(fmt.Sprint(k), func(t *testing.T) {
+			for i := 0; i < 200; i++ {
+				testLongestRepeatedSubstring(t, randomText(rand.Intn(100), k))
+			}
+		})
+	}
+}
+
+func FuzzLongestRepeatedSubstring(f *testing.F) {
+	f.Add([]byte("mississippi"))
+	f.Fuzz(func(t *testing.T, text []byte) {
+		if len(text) > 1000 {
+			return
+		}
+		testLongestRepeatedSubstring(t, text)
+	})
+}
+
+func FuzzRabinKarp(f *testing.F) {
+	f.Add("ushers", "he she his hers")
+	f.Fuzz(func(t *testing.T, text, patterns string) {
+		ps := strings.Split(patterns, " ")
+		want := naiveMatches(text, ps, OverlappingMatch, false)
+		if ms := NewRabinKarp(ps).FindAll([]byte(text)); !slices.Equal(ms, want) {
+			t.Errorf("FindAll(%q, %q) = %v; want %v", text, ps, ms, want)
+		}
+		for _, p := range ps {
+			if i, want := IndexRabinKarp([]byte(text), []byte(p)), strings.Index(text, p); i != want {
+				t.Errorf("IndexRabinKarp(%q, %q) = %d; want %d", text, p, i, want)
+			}
+		}
+	})
+}
+
+func BenchmarkRabinKarp(b *testing.B) {
+	// Many chunks of the same length in a text containing some of them.
+	const size = 32
+	text := randomText(1<<20, 26)
+	chunks := make([]string, 1000)
+	for i := range chunks {
+		chunks[i] = string(randomText(size, 26))
+		copy(text[rand.Intn(len(text)-size):], chunks[i])
+	}
+
+	b.Run("IndexRabinKarp", func(b *testing.B) {
+		pattern := []byte(chunks[0])
+		b.SetBytes(int64(len(text)))
+		for i := 0; i < b.N; i++ {
+			IndexRabinKarp(text, pattern)
+		}
+	})
+
+	rk := NewRabinKarp(chunks)
+	b.Run("RabinKarp", func(b *testing.B) {
+		b.SetBytes(int64(len(text)))
+		for i := 0; i < b.N; i++ {
+			rk.Find(text, func(Match) bool { return true })
+		}
+	})
+	ac := NewAhoCorasick(chunks, nil)
+	b.Run("AhoCorasick", func(b *testing.B) {
+		b.SetBytes(int64(len(text)))
+		for i := 0; i < b.N; i++ {
+			ac.Find(text, func(Match) bool { return true })
+		}
+	})
+
+	b.Run("LongestRepeatedSubstring", func(b *testing.B) {
+		b.SetBytes(int64(len(text)))
+		for i := 0; i < b.N; i++ {
+			LongestRepeatedSubstring(text)
+		}
+	})
+}
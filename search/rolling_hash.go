@@ -0,0 +1,255 @@
+package search
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+
+	"github.com/denpeshkov/algorithms/numeric"
+)
+
+const (
+	// mersenne61 is the Mersenne prime 2^61-1, the modulus of [PolynomialHash].
+	mersenne61 = 1<<61 - 1
+
+	// DefaultPolynomialBase is the base of [PolynomialHash] used if the base is 0.
+	DefaultPolynomialBase = 0x1f3d5b79a2c4e68
+
+	// DefaultRabinPolynomial is an irreducible polynomial of degree 53 over GF(2), the modulus of [RabinFingerprint].
+	DefaultRabinPolynomial = 0x3da3358b4dc173
+)
+
+/*
+RollingHash is a hash of a window of bytes, which can be slid over a text in O(1) time per byte.
+The first window is hashed by writing its bytes, then Roll slides the window by one byte.
+The hash only stores its value, so the caller must provide the byte leaving the window.
+*/
+type RollingHash interface {
+	hash.Hash64
+	// Roll removes the byte out from the start of the window and appends the byte in to its end.
+	// The window must have the size the hash was created with, and out must be its first byte.
+	Roll(out, in byte)
+}
+
+/*
+PolynomialHash is a polynomial rolling hash modulo the Mersenne prime 2^61-1:
+the hash of the bytes s[0], ..., s[n-1] is (s[0]+1)*b^(n-1) + (s[1]+1)*b^(n-2) + ... + (s[n-1]+1) mod 2^61-1,
+where b is the base. The bytes are incremented, so the leading zero bytes change the hash.
+The probability that the hashes of two different windows of length n are equal is at most n/2^61 for a random base.
+The multiplication modulo a Mersenne prime takes a few instructions, so it's fast.
+*/
+type PolynomialHash struct {
+	base uint64
+	pow  uint64 // base^(window-1), the weight of the first byte of the window
+	h    uint64
+}
+
+var _ RollingHash = (*PolynomialHash)(nil)
+
+/*
+NewPolynomialHash returns a polynomial rolling hash of windows of the size with the base.
+The base must be in the interval [2, 2^61-2]. If base is 0, DefaultPolynomialBase is used.
+The hashes of the same bytes with different bases differ, so a random base protects from inputs crafted to collide.
+*/
+func NewPolynomialHash(window int, base uint64) *PolynomialHash {
+	if base == 0 {
+		base = DefaultPolynomialBase
+	}
+	if base < 2 || base >= mersenne61-1 {
+		panic("search: polynomial hash base out of range")
+	}
+	if window <= 0 {
+		panic("search: invalid rolling hash window")
+	}
+	return &PolynomialHash{base: base, pow: numeric.FastExpMod(base, uint64(window-1), mersenne61)}
+}
+
+// Write appends the bytes to the hashed bytes. It always returns len(p), nil.
+func (h *PolynomialHash) Write(p []byte) (int, error) {
+	for _, c := range p {
+		h.h = addMod61(mulMod61(h.h, h.base), uint64(c)+1)
+	}
+	return len(p), nil
+}
+
+// Roll removes the byte out from the start of the window and appends the byte in to its end.
+func (h *PolynomialHash) Roll(out, in byte) {
+	h.h = addMod61(h.h, mersenne61-mulMod61(uint64(out)+1, h.pow))
+	h.h = addMod61(mulMod61(h.h, h.base), uint64(in)+1)
+}
+
+// Sum64 returns the hash.
+func (h *PolynomialHash) Sum64() uint64 {
+	return h.h
+}
+
+// Sum appends the big-endian bytes of the hash to b.
+func (h *PolynomialHash) Sum(b []byte) []byte {
+	return binary.BigEndian.AppendUint64(b, h.h)
+}
+
+// Reset resets the hash to the hash of no bytes.
+func (h *PolynomialHash) Reset() {
+	h.h = 0
+}
+
+// Size returns the number of bytes returned by Sum.
+func (h *PolynomialHash) Size() int {
+	return 8
+}
+
+// BlockSize returns 1, since the bytes are hashed one at a time.
+func (h *PolynomialHash) BlockSize() int {
+	return 1
+}
+
+// mulMod61 computes a*b mod 2^61-1 for a, b < 2^61-1.
+func mulMod61(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	// 2^61 = 1 mod 2^61-1, so the bits above the 61st are added to the lower ones.
+	r := (hi<<3 | lo>>61) + lo&mersenne61
+	if r >= mersenne61 {
+		r -= mersenne61
+	}
+	return r
+}
+
+// addMod61 computes a+b mod 2^61-1 for a, b <= 2^61-1.
+func addMod61(a, b uint64) uint64 {
+	r := a + b
+	if r >= mersenne61 {
+		r -= mersenne61
+	}
+	return r
+}
+
+/*
+RabinFingerprint is the Rabin fingerprint of a window of bytes: the bits of the bytes are the coefficients of a polynomial over GF(2),
+and the fingerprint is the remainder of its division by an irreducible polynomial.
+The probability that the fingerprints of two different windows of n bits are equal is at most n/2^(d-1)
+for a random irreducible polynomial of degree d. The arithmetic over GF(2) is done using XOR and lookup tables,
+so it's fast, and it's commonly used for content-defined chunking.
+
+See M. O. Rabin, "Fingerprinting by random polynomials", 1981.
+*/
+type RabinFingerprint struct {
+	deg int
+	h   uint64
+	mod [256]uint64 // mod[t] is t*x^deg mod poly, which reduces the bits above the degree
+	out [256]uint64 // out[c] is c*x^(8*(window-1)) mod poly, the weight of the first byte of the window
+}
+
+var _ RollingHash = (*RabinFingerprint)(nil)
+
+/*
+NewRabinFingerprint returns the Rabin fingerprint of windows of the size modulo the polynomial.
+The bit i of poly is the coefficient of x^i. The polynomial must be irreducible, and its degree must be in the interval [9, 56].
+If poly is 0, DefaultRabinPolynomial is used.
+*/
+func NewRabinFingerprint(window int, poly uint64) *RabinFingerprint {
+	if poly == 0 {
+		poly = DefaultRabinPolynomial
+	}
+	deg := bits.Len64(poly) - 1
+	if deg < 9 || deg > 56 {
+		panic("search: Rabin polynomial degree out of range")
+	}
+	if window <= 0 {
+		panic("search: invalid rolling hash window")
+	}
+
+	f := &RabinFingerprint{deg: deg}
+	for t := range f.mod {
+		f.mod[t] = polyMod(uint64(t)<<deg, poly)
+	}
+	pow := polyExpMod(1<<8, uint64(window-1), poly)
+	for c := range f.out {
+		f.out[c] = polyMulMod(uint64(c), pow, poly)
+	}
+	return f
+}
+
+// Write appends the bytes to the fingerprinted bytes. It always returns len(p), nil.
+func (f *RabinFingerprint) Write(p []byte) (int, error) {
+	for _, c := range p {
+		f.append(c)
+	}
+	return len(p), nil
+}
+
+// Roll removes the byte out from the start of the window and appends the byte in to its end.
+func (f *RabinFingerprint) Roll(out, in byte) {
+	f.h ^= f.out[out]
+	f.append(in)
+}
+
+// append appends the byte c to the fingerprinted bytes, that is, computes h*x^8 + c mod poly.
+func (f *RabinFingerprint) append(c byte) {
+	top := f.h >> (f.deg - 8)
+	f.h = (f.h<<8|uint64(c))&(1<<f.deg-1) ^ f.mod[top]
+}
+
+// Sum64 returns the fingerprint.
+func (f *RabinFingerprint) Sum64() uint64 {
+	return f.h
+}
+
+// Sum appends the big-endian bytes of the fingerprint to b.
+func (f *RabinFingerprint) Sum(b []byte) []byte {
+	return binary.BigEndian.AppendUint64(b, f.h)
+}
+
+// Reset resets the fingerprint to the fingerprint of no bytes.
+func (f *RabinFingerprint) Reset() {
+	f.h = 0
+}
+
+// Size returns the number of bytes returned by Sum.
+func (f *RabinFingerprint) Size() int {
+	return 8
+}
+
+// BlockSize returns 1, since the bytes are fingerprinted one at a time.
+func (f *RabinFingerprint) BlockSize() int {
+	return 1
+}
+
+// polyMod computes a mod p over GF(2).
+func polyMod(a, p uint64) uint64 {
+	deg := bits.Len64(p) - 1
+	for d := bits.Len64(a) - 1; d >= deg; d = bits.Len64(a) - 1 {
+		a ^= p << (d - deg)
+	}
+	return a
+}
+
+// polyMulMod computes a*b mod p over GF(2) for a, b of degrees less than the degree of p.
+func polyMulMod(a, b, p uint64) uint64 {
+	deg := bits.Len64(p) - 1
+	var r uint64
+	for ; b != 0; b >>= 1 {
+		if b&1 != 0 {
+			r ^= a
+		}
+		a <<= 1
+		if a>>deg&1 != 0 {
+			a ^= p
+		}
+	}
+	return r
+}
+
+// polyExpMod computes a^n mod p over GF(2) using exponentiation by squaring, the same way as [numeric.FastExpMod].
+func polyExpMod(a, n, p uint64) uint64 {
+	if n == 0 {
+		return 1
+	}
+
+	x := polyExpMod(a, n/2, p)
+	x = polyMulMod(x, x, p) // x^2
+
+	if n%2 == 0 {
+		return x
+	}
+	return polyMulMod(polyMod(a, p), x, p)
+}
@@ -0,0 +1,151 @@
+package search
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// naivePolynomialHash returns the polynomial hash of the bytes computed using big integers.
+func naivePolynomialHash(p []byte, base uint64) uint64 {
+	h, b, m := new(big.Int), new(big.Int).SetUint64(base), new(big.Int).SetUint64(mersenne61)
+	for _, c := range p {
+		h.Mul(h, b)
+		h.Add(h, big.NewInt(int64(c)+1))
+		h.Mod(h, m)
+	}
+	return h.Uint64()
+}
+
+// naiveRabinFingerprint returns the Rabin fingerprint of the bytes computed bit by bit.
+func naiveRabinFingerprint(p []byte, poly uint64) uint64 {
+	deg := 0
+	for poly>>deg > 1 {
+		deg++
+	}
+	var h uint64
+	for _, c := range p {
+		for i := 7; i >= 0; i-- {
+			h = h<<1 | uint64(c>>i&1)
+			if h>>deg&1 != 0 {
+				h ^= poly
+			}
+		}
+	}
+	return h
+}
+
+func TestRollingHash(t *testing.T) {
+	const (
+		base = 12345678910111213
+		// x^31 + x^3 + 1 is irreducible over GF(2).
+		poly = 1<<31 | 1<<3 | 1
+	)
+	hashes := map[string]struct {
+		new   func(window int) RollingHash
+		naive func(p []byte) uint64
+	}{
+		"polynomial hash/default": {
+			new:   func(w int) RollingHash { return NewPolynomialHash(w, 0) },
+			naive: func(p []byte) uint64 { return naivePolynomialHash(p, DefaultPolynomialBase) },
+		},
+		"polynomial hash/base": {
+			new:   func(w int) RollingHash { return NewPolynomialHash(w, base) },
+			naive: func(p []byte) uint64 { return naivePolynomialHash(p, base) },
+		},
+		"rabin fingerprint/default": {
+			new:   func(w int) RollingHash { return NewRabinFingerprint(w, 0) },
+			naive: func(p []byte) uint64 { return naiveRabinFingerprint(p, DefaultRabinPolynomial) },
+		},
+		"rabin fingerprint/poly": {
+			new:   func(w int) RollingHash { return NewRabinFingerprint(w, poly) },
+			naive: func(p []byte) uint64 { return naiveRabinFingerprint(p, poly) },
+		},
+	}
+
+	text := make([]byte, 1000)
+	rand.Read(text)
+	for name, h := range hashes {
+		for _, w := range []int{1, 2, 7, 8, 64, 999} {
+			t.Run(fmt.Sprintf("%s/%d", name, w), func(t *testing.T) {
+				r := h.new(w)
+				r.Write(text[:w])
+				for i := 0; ; i++ {
+					if s, want := r.Sum64(), h.naive(text[i:i+w]); s != want {
+						t.Fatalf("hash of text[%d:%d] = %#x; want %#x", i, i+w, s, want)
+					}
+					if i+w == len(text) {
+						break
+					}
+					r.Roll(text[i], text[i+w])
+				}
+			})
+		}
+	}
+}
+
+func TestRollingHash_Sum(t *testing.T) {
+	for _, h := range []RollingHash{NewPolynomialHash(3, 0), NewRabinFingerprint(3, 0)} {
+		h.Write([]byte("abc"))
+		s := h.Sum64()
+		if b := h.Sum([]byte{1}); len(b) != 1+h.Size() || new(big.Int).SetBytes(b[1:]).Uint64() != s {
+			t.Errorf("%T: Sum = %x; want 01 followed by %016x", h, b, s)
+		}
+
+		h.Reset()
+		if s := h.Sum64(); s != 0 {
+			t.Errorf("%T: Sum64 after Reset = %#x; want 0", h, s)
+		}
+	}
+
+	// The leading zero bytes change the polynomial hash.
+	a, b := NewPolynomialHash(2, 0), NewPolynomialHash(1, 0)
+	a.Write([]byte{0, 1})
+	b.Write([]byte{1})
+	if a.Sum64() == b.Sum64() {
+		t.Errorf("hash of [0 1] = hash of [1] = %#x", a.Sum64())
+	}
+}
+
+func TestRollingHash_Panics(t *testing.T) {
+	tests := map[string]func(){
+		"polynomial hash/base=1":      func() { NewPolynomialHash(1, 1) },
+		"polynomial hash/base=2^61-1": func() { NewPolynomialHash(1, mersenne61) },
+		"polynomial hash/window=0":    func() { NewPolynomialHash(0, 0) },
+		"rabin fingerprint/degree=8":  func() { NewRabinFingerprint(1, 1<<8|1) },
+		"rabin fingerprint/degree=57": func() { NewRabinFingerprint(1, 1<<57|1) },
+		"rabin fingerprint/window=-1": func() { NewRabinFingerprint(-1, 0) },
+	}
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("no panic")
+				}
+			}()
+			f()
+		})
+	}
+}
+
+func BenchmarkRollingHash(b *testing.B) {
+	text := make([]byte, 1<<20)
+	rand.Read(text)
+	const w = 64
+	for name, h := range map[string]RollingHash{
+		"PolynomialHash":   NewPolynomialHash(w, 0),
+		"RabinFingerprint": NewRabinFingerprint(w, 0),
+	} {
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(text) - w))
+			for i := 0; i < b.N; i++ {
+				h.Reset()
+				h.Write(text[:w])
+				for j := w; j < len(text); j++ {
+					h.Roll(text[j-w], text[j])
+				}
+			}
+		})
+	}
+}
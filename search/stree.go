@@ -0,0 +1,147 @@
+package search
+
+import (
+	"cmp"
+	"unsafe"
+)
+
+// cacheLine is the assumed size of a cache line in bytes.
+const cacheLine = 64
+
+/*
+STree is a static search index of a sorted slice in the S+ tree layout: an implicit B+ tree with nodes of the size of a cache line.
+The last layer of the tree is the slice itself, split into nodes of b elements, and each node of the upper layers
+stores the smallest elements of the subtrees of all its b+1 children but the first.
+The layers are stored one after another starting from the root, and the children of the node with index k in a layer
+have indexes k*(b+1)+j in the next layer, so a search reads a single cache line at each of the log_{b+1}(n) layers,
+and compares the target with all elements of the node without branches.
+It's faster than [LowerBound] and [Binary] on slices of any size, since it reads fewer cache lines.
+The index uses about 1/b more space than the slice.
+The elements are iterated in increasing order using [STree.All] and [STree.Ascend], which read the last layer sequentially.
+
+See https://en.algorithmica.org/hpc/data-structures/s-tree/.
+*/
+type STree[E cmp.Ordered] struct {
+	keys    []E   // the layers of the tree, from the root to the last one
+	offsets []int // offsets[h] is the index of the first node of the h-th layer from the last one in keys
+	b       int   // the number of elements in a node
+	n       int
+	last    E // the largest element
+}
+
+/*
+NewSTree returns the S+ tree index of the slice. It runs in O(n) time.
+The slice must be sorted in increasing order. It's not used after NewSTree returns.
+*/
+func NewSTree[E cmp.Ordered](x []E) *STree[E] {
+	var zero E
+	n := len(x)
+	t := &STree[E]{b: max(2, cacheLine/int(unsafe.Sizeof(zero))), n: n}
+	if n == 0 {
+		return t
+	}
+	b := t.b
+	t.last = x[n-1]
+
+	// The number of nodes in each layer, from the last one.
+	nodes := []int{(n + b - 1) / b}
+	for nodes[len(nodes)-1] > 1 {
+		nodes = append(nodes, (nodes[len(nodes)-1]+b)/(b+1))
+	}
+	t.offsets = make([]int, len(nodes))
+	size := 0
+	for h := len(nodes) - 1; h >= 0; h-- {
+		t.offsets[h] = size
+		size += nodes[h] * b
+	}
+	t.keys = make([]E, size)
+
+	// The missing elements are padded with the largest element, which is never less than the target of a search.
+	last := t.keys[t.offsets[0]:]
+	copy(last, x)
+	for i := n; i < len(last); i++ {
+		last[i] = t.last
+	}
+
+	// span is the number of elements of the last layer in the subtree of a node of the layer h-1.
+	span := b
+	for h := 1; h < len(nodes); h++ {
+		layer := t.keys[t.offsets[h] : t.offsets[h]+nodes[h]*b]
+		for i := range layer {
+			// The element j of the node k is the smallest element of the subtree of its child j+1.
+			k, j := i/b, i%b
+			if first := (k*(b+1) + j + 1) * span; first < n {
+				layer[i] = x[first]
+			} else {
+				layer[i] = t.last
+			}
+		}
+		span *= b + 1
+	}
+
+	return t
+}
+
+// Len returns the number of elements in the index.
+func (t *STree[E]) Len() int {
+	return t.n
+}
+
+/*
+LowerBound returns the index of the first element in the indexed slice that is not less than the target.
+If all elements are less than the target, it returns the length of the slice.
+It's the same as [LowerBound] on the indexed slice.
+*/
+func (t *STree[E]) LowerBound(target E) int {
+	if t.n == 0 || t.last < target {
+		return t.n
+	}
+
+	b := t.b
+	k := 0
+	for h := len(t.offsets) - 1; h > 0; h-- {
+		// The elements of the node are sorted, so the number of the elements less than the target is the index of the child to descend.
+		k = k*(b+1) + rankInNode(t.keys[t.offsets[h]+k*b:][:b], target)
+	}
+
+	return k*b + rankInNode(t.keys[t.offsets[0]+k*b:][:b], target)
+}
+
+/*
+All calls fn with the indexes and the elements of the indexed slice in increasing order, until fn returns false.
+The last layer of the tree is the slice itself, so it's read sequentially.
+*/
+func (t *STree[E]) All(fn func(i int, v E) bool) {
+	t.ascend(0, fn)
+}
+
+/*
+Ascend calls fn with the indexes and the elements of the indexed slice that are not less than the target in increasing order,
+starting from the index returned by [STree.LowerBound], until fn returns false.
+*/
+func (t *STree[E]) Ascend(target E, fn func(i int, v E) bool) {
+	t.ascend(t.LowerBound(target), fn)
+}
+
+// ascend calls fn with the elements of the last layer starting from the index i, until fn returns false.
+func (t *STree[E]) ascend(i int, fn func(i int, v E) bool) {
+	if t.n == 0 {
+		return
+	}
+
+	x := t.keys[t.offsets[0]:][:t.n]
+	for ; i < len(x); i++ {
+		if !fn(i, x[i]) {
+			return
+		}
+	}
+}
+
+// rankInNode returns the number of elements of the node that are less than the target.
+func rankInNode[E cmp.Ordered](node []E, target E) int {
+	r := 0
+	for _, v := range node {
+		r += b2i(v < target)
+	}
+	return r
+}
@@ -0,0 +1,225 @@
+package search
+
+import "slices"
+
+// Symbol is a constraint that permits the types of the symbols of texts indexed by suffix arrays.
+type Symbol interface {
+	~byte | ~int32
+}
+
+/*
+SuffixArray returns the suffix array of the text: the starting indexes of all suffixes of the text in lexicographic order.
+A suffix that is a prefix of another suffix is less than it.
+It uses the SA-IS algorithm, which runs in O(n) time for bytes. The int32 symbols are first replaced by their ranks among the distinct symbols
+of the text in O(n*log(n)) time, unless they're in the interval [0, max(256, n)).
+
+See G. Nong, S. Zhang and W. H. Chan, "Two Efficient Algorithms for Linear Time Suffix Array Construction", 2011.
+*/
+func SuffixArray[T Symbol](text []T) []int {
+	if len(text) == 0 {
+		return []int{}
+	}
+
+	lo, hi := slices.Min(text), slices.Max(text)
+	if lo >= 0 && int(hi) < max(256, len(text)) {
+		return sais(text, int(hi)+1)
+	}
+	s, k := symbolRanks(text, 0)
+	return sais(s, k)
+}
+
+// symbolRanks returns the ranks of the symbols of the text among its distinct symbols increased by shift,
+// and the number of the distinct symbols. It runs in O(n*log(n)) time.
+func symbolRanks[T Symbol](text []T, shift int32) ([]int32, int) {
+	alphabet := slices.Clone(text)
+	slices.Sort(alphabet)
+	alphabet = slices.Compact(alphabet)
+	s := make([]int32, len(text))
+	for i, c := range text {
+		r, _ := slices.BinarySearch(alphabet, c)
+		s[i] = int32(r) + shift
+	}
+	return s, len(alphabet)
+}
+
+/*
+sais returns the suffix array of the text with symbols in the interval [0, k) using the SA-IS algorithm.
+The text is terminated by a virtual sentinel less than all symbols. The suffixes are classified as S-type, if they're less than the next suffix,
+or L-type otherwise, and the leftmost S-type suffixes (LMS) preceded by an L-type suffix are sorted first.
+The order of all suffixes is induced from them by scanning the array twice, and the LMS suffixes are sorted
+by sorting the LMS substrings between them the same way and recursing on their names if they're not unique.
+*/
+func sais[T Symbol](s []T, k int) []int {
+	n := len(s)
+	sa := make([]int, n)
+	if n == 0 {
+		return sa
+	}
+
+	// stype[i] reports whether the suffix i is S-type. The last suffix is greater than the sentinel, so it's L-type.
+	stype := make([]bool, n)
+	for i := n - 2; i >= 0; i-- {
+		stype[i] = s[i] < s[i+1] || s[i] == s[i+1] && stype[i+1]
+	}
+	isLMS := func(i int) bool {
+		return i > 0 && stype[i] && !stype[i-1]
+	}
+
+	// The suffixes starting with the same symbol form a bucket, the L-type ones at its start and the S-type ones at its end.
+	counts := make([]int, k)
+	for _, c := range s {
+		counts[c]++
+	}
+	bkt := make([]int, k)
+	starts := func() {
+		sum := 0
+		for c, cnt := range counts {
+			bkt[c] = sum
+			sum += cnt
+		}
+	}
+	ends := func() {
+		sum := 0
+		for c, cnt := range counts {
+			sum += cnt
+			bkt[c] = sum
+		}
+	}
+
+	// induce sorts all suffixes given the LMS suffixes placed at the ends of their buckets.
+	induce := func() {
+		starts()
+		// The suffix preceding the sentinel is the first L-type suffix.
+		sa[bkt[s[n-1]]] = n - 1
+		bkt[s[n-1]]++
+		for i := 0; i < n; i++ {
+			if j := sa[i] - 1; j >= 0 && !stype[j] {
+				sa[bkt[s[j]]] = j
+				bkt[s[j]]++
+			}
+		}
+
+		ends()
+		for i := n - 1; i >= 0; i-- {
+			if j := sa[i] - 1; j >= 0 && stype[j] {
+				bkt[s[j]]--
+				sa[bkt[s[j]]] = j
+			}
+		}
+	}
+
+	// Sort the LMS substrings.
+	for i := range sa {
+		sa[i] = -1
+	}
+	ends()
+	for i := 1; i < n; i++ {
+		if isLMS(i) {
+			bkt[s[i]]--
+			sa[bkt[s[i]]] = i
+		}
+	}
+	induce()
+
+	// Name the LMS substrings by their ranks. The LMS suffixes are at least two apart, so the name of the suffix i is stored at sa[m+i/2].
+	m := 0
+	for _, i := range sa {
+		if isLMS(i) {
+			sa[m] = i
+			m++
+		}
+	}
+	for i := m; i < n; i++ {
+		sa[i] = -1
+	}
+	names, prev := 0, -1
+	for _, i := range sa[:m] {
+		if prev < 0 || !equalLMS(s, stype, isLMS, i, prev) {
+			names++
+			prev = i
+		}
+		sa[m+i/2] = names - 1
+	}
+	s1 := make([]int32, 0, m)
+	for _, name := range sa[m:] {
+		if name >= 0 {
+			s1 = append(s1, int32(name))
+		}
+	}
+
+	// Sort the LMS suffixes by the suffixes of the names.
+	var sa1 []int
+	if names < m {
+		sa1 = sais(s1, names)
+	} else {
+		sa1 = make([]int, m)
+		for i, name := range s1 {
+			sa1[name] = i
+		}
+	}
+	lms := s1[:0]
+	for i := 1; i < n; i++ {
+		if isLMS(i) {
+			lms = append(lms, int32(i))
+		}
+	}
+
+	// Induce the order of all suffixes from the LMS suffixes.
+	for i := range sa {
+		sa[i] = -1
+	}
+	ends()
+	for i := m - 1; i >= 0; i-- {
+		j := int(lms[sa1[i]])
+		bkt[s[j]]--
+		sa[bkt[s[j]]] = j
+	}
+	induce()
+
+	return sa
+}
+
+// equalLMS reports whether the LMS substrings starting at i and j, including the next LMS symbols, are equal.
+func equalLMS[T Symbol](s []T, stype []bool, isLMS func(int) bool, i, j int) bool {
+	for d := 0; ; d++ {
+		// The sentinel is unique, so the substrings reaching it aren't equal.
+		if i+d == len(s) || j+d == len(s) || s[i+d] != s[j+d] || stype[i+d] != stype[j+d] {
+			return false
+		}
+		if d > 0 && (isLMS(i+d) || isLMS(j+d)) {
+			return isLMS(i+d) && isLMS(j+d)
+		}
+	}
+}
+
+/*
+LCPArray returns the longest common prefix array of the text with the suffix array sa, as returned by [SuffixArray]:
+the element i is the length of the longest common prefix of the suffixes sa[i-1] and sa[i], and the element 0 is 0.
+It uses the Kasai algorithm, which runs in O(n) time, since the longest common prefix of the suffix i+1 with its predecessor
+is at least that of the suffix i decreased by one.
+
+See T. Kasai et al., "Linear-Time Longest-Common-Prefix Computation in Suffix Arrays and Its Applications", 2001.
+*/
+func LCPArray[T Symbol](text []T, sa []int) []int {
+	n := len(text)
+	rank := make([]int, n)
+	for r, i := range sa {
+		rank[i] = r
+	}
+
+	lcp := make([]int, n)
+	h := 0
+	for i := 0; i < n; i++ {
+		if rank[i] == 0 {
+			h = 0
+			continue
+		}
+		j := sa[rank[i]-1]
+		for i+h < n && j+h < n && text[i+h] == text[j+h] {
+			h++
+		}
+		lcp[rank[i]] = h
+		h = max(h-1, 0)
+	}
+	return lcp
+}
@@ -0,0 +1,262 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// naiveSuffixArray returns the suffix array of the text by sorting the suffixes.
+func naiveSuffixArray[T Symbol](text []T) []int {
+	sa := make([]int, len(text))
+	for i := range sa {
+		sa[i] = i
+	}
+	slices.SortFunc(sa, func(i, j int) int {
+		return slices.Compare(text[i:], text[j:])
+	})
+	return sa
+}
+
+// naiveLCPArray returns the longest common prefix array of the text with the suffix array by comparing the adjacent suffixes.
+func naiveLCPArray[T Symbol](text []T, sa []int) []int {
+	lcp := make([]int, len(sa))
+	for r := 1; r < len(sa); r++ {
+		for sa[r-1]+lcp[r] < len(text) && sa[r]+lcp[r] < len(text) && text[sa[r-1]+lcp[r]] == text[sa[r]+lcp[r]] {
+			lcp[r]++
+		}
+	}
+	return lcp
+}
+
+// naiveLongestCommonSubstring returns the length of the longest common substring of the texts.
+func naiveLongestCommonSubstring[T Symbol](a, b []T) int {
+	best := 0
+	for i := range a {
+		for j := range b {
+			n := 0
+			for i+n < len(a) && j+n < len(b) && a[i+n] == b[j+n] {
+				n++
+			}
+			best = max(best, n)
+		}
+	}
+	return best
+}
+
+func testSuffixArray[T Symbol](t *testing.T, text []T) {
+	t.Helper()
+	want := naiveSuffixArray(text)
+	sa := SuffixArray(text)
+	if !slices.Equal(sa, want) {
+		t.Fatalf("SuffixArray(%v) = %v; want %v", text, sa, want)
+	}
+	if lcp, want := LCPArray(text, sa), naiveLCPArray(text, sa); !slices.Equal(lcp, want) {
+		t.Fatalf("LCPArray(%v) = %v; want %v", text, lcp, want)
+	}
+}
+
+func TestSuffixArray(t *testing.T) {
+	tests := []string{"", "a", "aa", "ab", "ba", "banana", "mississippi", "abracadabra", strings.Repeat("a", 100), strings.Repeat("ab", 50), strings.Repeat("abaab", 30)}
+	for _, text := range tests {
+		t.Run(text, func(t *testing.T) {
+			testSuffixArray(t, []byte(text))
+		})
+	}
+}
+
+func TestSuffixArray_Random(t *testing.T) {
+	for _, k := range []int{1, 2, 3, 26} {
+		t.Run(fmt.Sprint(k), func(t *testing.T) {
+			for i := 0; i < 300; i++ {
+				testSuffixArray(t, randomText(rand.Intn(300), k))
+			}
+		})
+	}
+	t.Run("bytes", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			text := make([]byte, rand.Intn(300))
+			rand.Read(text)
+			testSuffixArray(t, text)
+		}
+	})
+}
+
+func TestSuffixArray_Int32(t *testing.T) {
+	tests := map[string]func(n int) int32{
+		"small":    func(int) int32 { return rand.Int31n(3) },
+		"dense":    func(n int) int32 { return rand.Int31n(int32(n) + 1) },
+		"negative": func(int) int32 { return rand.Int31n(5) - 2 },
+		"sparse":   func(int) int32 { return []int32{math.MinInt32, -1, 1000, math.MaxInt32}[rand.Intn(4)] },
+	}
+	for name, sym := range tests {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 200; i++ {
+				text := make([]int32, rand.Intn(300))
+				for j := range text {
+					text[j] = sym(len(text))
+				}
+				testSuffixArray(t, text)
+			}
+		})
+	}
+}
+
+func TestSuffixIndex(t *testing.T) {
+	text := []byte("abracadabra")
+	x := NewSuffixIndex(text)
+	if n := x.Len(); n != len(text) {
+		t.Errorf("Len() = %d; want %d", n, len(text))
+	}
+
+	tests := map[string]struct {
+		pattern string
+		want    []int
+	}{
+		"empty":          {"", []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}},
+		"symbol":         {"a", []int{0, 3, 5, 7, 10}},
+		"prefix":         {"abra", []int{0, 7}},
+		"suffix":         {"ra", []int{2, 9}},
+		"equal":          {"abracadabra", []int{0}},
+		"longer pattern": {"abracadabrab", nil},
+		"absent":         {"abc", nil},
+		"less":           {"0", nil},
+		"greater":        {"z", nil},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if inds := x.Lookup([]byte(tt.pattern)); !slices.Equal(inds, tt.want) {
+				t.Errorf("Lookup(%q) = %v; want %v", tt.pattern, inds, tt.want)
+			}
+			if n := x.Count([]byte(tt.pattern)); n != len(tt.want) {
+				t.Errorf("Count(%q) = %d; want %d", tt.pattern, n, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestSuffixIndex_Random(t *testing.T) {
+	for _, k := range []int{2, 3, 26} {
+		t.Run(fmt.Sprint(k), func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				text := randomText(rand.Intn(300), k)
+				x := NewSuffixIndex(text)
+				for j := 0; j < 10; j++ {
+					pattern := randomText(1+rand.Intn(5), k)
+					want := naiveIndexAll(text, pattern, true)
+					if inds := x.Lookup(pattern); !slices.Equal(inds, want) {
+						t.Fatalf("Lookup(%q) in %q = %v; want %v", pattern, text, inds, want)
+					}
+					if n := x.Count(pattern); n != len(want) {
+						t.Fatalf("Count(%q) in %q = %d; want %d", pattern, text, n, len(want))
+					}
+				}
+
+				// LongestRepeatedSubstring is checked against the naive algorithm by its own tests.
+				i, j, n := x.LongestRepeatedSubstring()
+				if wi, wj, wn := LongestRepeatedSubstring(text); i != wi || j != wj || n != wn {
+					t.Fatalf("LongestRepeatedSubstring() of %q = %d, %d, %d; want %d, %d, %d", text, i, j, n, wi, wj, wn)
+				}
+			}
+		})
+	}
+}
+
+func testLongestCommonSubstring[T Symbol](t *testing.T, a, b []T) {
+	t.Helper()
+	i, j, n := LongestCommonSubstring(a, b)
+	if want := naiveLongestCommonSubstring(a, b); n != want {
+		t.Fatalf("LongestCommonSubstring(%v, %v) = %d, %d, %d; want the length %d", a, b, i, j, n, want)
+	}
+	if n > 0 && (i+n > len(a) || j+n > len(b) || !slices.Equal(a[i:i+n], b[j:j+n])) {
+		t.Fatalf("LongestCommonSubstring(%v, %v) = %d, %d, %d; not a common substring", a, b, i, j, n)
+	}
+}
+
+func TestLongestCommonSubstring(t *testing.T) {
+	tests := map[string]struct {
+		a, b string
+		i, j int
+		n    int
+	}{
+		"empty":       {"", "", 0, 0, 0},
+		"empty first": {"", "abc", 0, 0, 0},
+		"disjoint":    {"abc", "xyz", 0, 0, 0},
+		"equal":       {"abc", "abc", 0, 0, 3},
+		"inner":       {"xabcdy", "zzabcdzz", 1, 2, 4},
+		"ends":        {"abcxyz", "xyzabc", 0, 3, 3},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if i, j, n := LongestCommonSubstring([]byte(tt.a), []byte(tt.b)); n != tt.n || n > 0 && (i != tt.i || j != tt.j) {
+				t.Errorf("LongestCommonSubstring(%q, %q) = %d, %d, %d; want %d, %d, %d", tt.a, tt.b, i, j, n, tt.i, tt.j, tt.n)
+			}
+		})
+	}
+
+	for i := 0; i < 300; i++ {
+		testLongestCommonSubstring(t, randomText(rand.Intn(100), 3), randomText(rand.Intn(100), 3))
+	}
+	for i := 0; i < 100; i++ {
+		a, b := make([]int32, rand.Intn(100)), make([]int32, rand.Intn(100))
+		for j := range a {
+			a[j] = rand.Int31n(4) - 2
+		}
+		for j := range b {
+			b[j] = rand.Int31n(4) - 2
+		}
+		testLongestCommonSubstring(t, a, b)
+	}
+}
+
+func FuzzSuffixArray(f *testing.F) {
+	f.Add([]byte("mississippi"), []byte("ssi"))
+	f.Fuzz(func(t *testing.T, text, pattern []byte) {
+		testSuffixArray(t, text)
+		if len(pattern) > 0 {
+			if inds, want := NewSuffixIndex(text).Lookup(pattern), naiveIndexAll(text, pattern, true); !slices.Equal(inds, want) {
+				t.Errorf("Lookup(%q) in %q = %v; want %v", pattern, text, inds, want)
+			}
+		}
+	})
+}
+
+func BenchmarkSuffixArray(b *testing.B) {
+	for _, n := range []int{1e3, 1e5, 1e7} {
+		texts := map[string][]byte{
+			"random":   randomText(n, 26),
+			"binary":   randomText(n, 2),
+			"periodic": []byte(strings.Repeat("abaab", n/5)),
+		}
+		for _, name := range []string{"random", "binary", "periodic"} {
+			text := texts[name]
+			b.Run(fmt.Sprintf("%s/%d", name, n), func(b *testing.B) {
+				b.SetBytes(int64(len(text)))
+				for i := 0; i < b.N; i++ {
+					SuffixArray(text)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkSuffixIndex(b *testing.B) {
+	text := randomText(1<<20, 4)
+	pattern := text[1000:1012]
+	x := NewSuffixIndex(text)
+	p := Compile(TwoWaySearch, pattern)
+
+	b.Run("Lookup", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.Lookup(pattern)
+		}
+	})
+	b.Run("Pattern.IndexAll", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.IndexAll(text)
+		}
+	})
+}
@@ -0,0 +1,140 @@
+package search
+
+import "slices"
+
+/*
+SuffixIndex is a full-text index of a static text: its suffix array and longest common prefix array.
+The occurrences of a pattern are the suffixes starting with it, which are adjacent in the suffix array,
+so they're found using two binary searches in O(m*log(n)) time, where n and m are the lengths of the text and the pattern.
+It's much faster than scanning the text for repeated searches in a large text, but uses 2 words of memory per symbol.
+A SuffixIndex is safe for concurrent use by multiple goroutines.
+*/
+type SuffixIndex[T Symbol] struct {
+	text []T
+	sa   []int
+	lcp  []int
+}
+
+/*
+NewSuffixIndex returns the suffix index of the text. It runs in O(n) time for bytes, as described in [SuffixArray] and [LCPArray].
+The text isn't copied, so it must not be modified while the index is used.
+*/
+func NewSuffixIndex[T Symbol](text []T) *SuffixIndex[T] {
+	sa := SuffixArray(text)
+	return &SuffixIndex[T]{text: text, sa: sa, lcp: LCPArray(text, sa)}
+}
+
+// Len returns the length of the indexed text.
+func (x *SuffixIndex[T]) Len() int {
+	return len(x.text)
+}
+
+// Lookup returns the indexes of all occurrences of the pattern in the text, including the overlapping ones, in increasing order.
+// The empty pattern occurs at every index from 0 to len(text).
+func (x *SuffixIndex[T]) Lookup(pattern []T) []int {
+	if len(pattern) == 0 {
+		inds := make([]int, len(x.text)+1)
+		for i := range inds {
+			inds[i] = i
+		}
+		return inds
+	}
+
+	lo, hi := x.lookup(pattern)
+	inds := slices.Clone(x.sa[lo:hi])
+	slices.Sort(inds)
+	return inds
+}
+
+// Count returns the number of occurrences of the pattern in the text, including the overlapping ones.
+// The empty pattern occurs len(text)+1 times. Unlike [SuffixIndex.Lookup], it runs in O(m*log(n)) time regardless of the number of occurrences.
+func (x *SuffixIndex[T]) Count(pattern []T) int {
+	if len(pattern) == 0 {
+		return len(x.text) + 1
+	}
+
+	lo, hi := x.lookup(pattern)
+	return hi - lo
+}
+
+// lookup returns the range [lo, hi) of the suffix array of the suffixes starting with the non-empty pattern.
+func (x *SuffixIndex[T]) lookup(pattern []T) (lo, hi int) {
+	lo = BinaryPredicate(x.sa, func(i int) bool {
+		return comparePrefix(x.text[i:], pattern) >= 0
+	})
+	hi = lo + BinaryPredicate(x.sa[lo:], func(i int) bool {
+		return comparePrefix(x.text[i:], pattern) > 0
+	})
+	return lo, hi
+}
+
+// comparePrefix compares the prefix of the suffix of the length of the pattern with the pattern.
+func comparePrefix[T Symbol](suffix, pattern []T) int {
+	return slices.Compare(suffix[:min(len(suffix), len(pattern))], pattern)
+}
+
+/*
+LongestRepeatedSubstring returns the longest substring occurring in the text at least twice, possibly overlapping,
+as the indexes i < j of its first two occurrences and its length n, so text[i:i+n] == text[j:j+n].
+If there are several such substrings, it returns the one whose second occurrence is the leftmost.
+If no symbol of the text is repeated, it returns n = 0.
+It's the same as [LongestRepeatedSubstring], but runs in O(n) time: the length is the largest element of the longest common prefix array,
+and the occurrences of each substring of the length are adjacent in the suffix array.
+*/
+func (x *SuffixIndex[T]) LongestRepeatedSubstring() (i, j, n int) {
+	if len(x.lcp) > 0 {
+		n = slices.Max(x.lcp)
+	}
+	if n == 0 {
+		return 0, 0, 0
+	}
+
+	j = len(x.text)
+	for r := 1; r < len(x.sa); {
+		if x.lcp[r] < n {
+			r++
+			continue
+		}
+
+		// The adjacent suffixes with the common prefixes of length n start with the same substring.
+		first, second := min(x.sa[r-1], x.sa[r]), max(x.sa[r-1], x.sa[r])
+		for r++; r < len(x.sa) && x.lcp[r] == n; r++ {
+			if p := x.sa[r]; p < first {
+				first, second = p, first
+			} else if p < second {
+				second = p
+			}
+		}
+		if second < j {
+			i, j = first, second
+		}
+	}
+	return i, j, n
+}
+
+/*
+LongestCommonSubstring returns the longest substring occurring in both texts as the indexes i and j of its occurrences in them
+and its length n, so a[i:i+n] == b[j:j+n]. If the texts have no common symbols, it returns n = 0.
+It builds the suffix array of the texts joined by a unique separator, in which the longest common substring is the longest common prefix
+of two adjacent suffixes starting in different texts, so it runs in O(n*log(n)) time, where n is the total length of the texts.
+*/
+func LongestCommonSubstring[T Symbol](a, b []T) (i, j, n int) {
+	// The ranks of the symbols start from 1, so 0 is the separator.
+	ranks, k := symbolRanks(slices.Concat(a, b), 1)
+	s := make([]int32, len(a)+1+len(b))
+	copy(s, ranks[:len(a)])
+	copy(s[len(a)+1:], ranks[len(a):])
+
+	sa := sais(s, k+1)
+	lcp := LCPArray(s, sa)
+	// The separator is unique, so the common prefixes don't contain it.
+	for r := 1; r < len(sa); r++ {
+		p, q := sa[r-1], sa[r]
+		if (p < len(a)) == (q < len(a)) || lcp[r] <= n {
+			continue
+		}
+		n = lcp[r]
+		i, j = min(p, q), max(p, q)-len(a)-1
+	}
+	return i, j, n
+}
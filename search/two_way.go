@@ -0,0 +1,155 @@
+package search
+
+// twoWay implements the Crochemore–Perrin Two-Way algorithm.
+// It splits the pattern at its critical factorization and matches the right part from left to right,
+// then the left part from right to left, so it runs in O(n) time and O(1) space,
+// where n is the length of the text, and compares each element of the text at most twice.
+//
+// See M. Crochemore and D. Perrin, "Two-way string-matching", 1991.
+type twoWay[T comparable] struct {
+	pattern []T
+	// The critical factorization is pattern[:ell+1] and pattern[ell+1:].
+	ell int
+	// per is the period of the pattern if the pattern is periodic,
+	// otherwise it's a lower bound of the period, which is a safe shift.
+	per      int
+	periodic bool
+}
+
+func newTwoWay[T comparable](pattern []T) *twoWay[T] {
+	m := len(pattern)
+
+	// The critical factorization requires an order of the elements, so the elements of the pattern
+	// are ordered by their first occurrence in it. The elements of the text are only compared for equality.
+	ranks := make(map[T]int)
+	order := make([]int, m)
+	for i, c := range pattern {
+		r, ok := ranks[c]
+		if !ok {
+			r = len(ranks)
+			ranks[c] = r
+		}
+		order[i] = r
+	}
+
+	// The maximal suffixes for the order and for the reverse order, one of which gives the critical factorization.
+	i, p := maximalSuffix(order, false)
+	j, q := maximalSuffix(order, true)
+	ell, per := j, q
+	if i > j {
+		ell, per = i, p
+	}
+
+	tw := &twoWay[T]{pattern: pattern, ell: ell}
+	// The pattern is periodic with the period per if its left part is a suffix of pattern[:per+ell+1].
+	if per+ell+1 <= m && equal(pattern[:ell+1], pattern[per:per+ell+1]) {
+		tw.per, tw.periodic = per, true
+	} else {
+		tw.per = max(ell+1, m-ell-1) + 1
+	}
+	return tw
+}
+
+// maximalSuffix returns the index of the element before the lexicographically maximal suffix of x
+// for the order, or the reverse order if reverse is true, and the period of the suffix.
+func maximalSuffix(x []int, reverse bool) (ms, p int) {
+	ms, j, k, p := -1, 0, 1, 1
+	for j+k < len(x) {
+		a, b := x[j+k], x[ms+k]
+		if reverse {
+			a, b = b, a
+		}
+
+		switch {
+		case a < b:
+			j += k
+			k = 1
+			p = j - ms
+		case a == b:
+			if k != p {
+				k++
+			} else {
+				j += p
+				k = 1
+			}
+		default:
+			ms = j
+			j = ms + 1
+			k, p = 1, 1
+		}
+	}
+	return ms, p
+}
+
+func (p *twoWay[T]) scan(text []T, overlap bool, yield func(i int) bool) {
+	if p.periodic {
+		p.scanPeriodic(text, overlap, yield)
+		return
+	}
+
+	m, n, ell := len(p.pattern), len(text), p.ell
+	for j := 0; j <= n-m; {
+		// Match the right part from left to right.
+		i := ell + 1
+		for i < m && p.pattern[i] == text[i+j] {
+			i++
+		}
+		if i < m {
+			j += i - ell
+			continue
+		}
+
+		// Match the left part from right to left.
+		i = ell
+		for i >= 0 && p.pattern[i] == text[i+j] {
+			i--
+		}
+		if i < 0 {
+			if !yield(j) {
+				return
+			}
+			if !overlap {
+				j += m
+				continue
+			}
+		}
+		j += p.per
+	}
+}
+
+// scanPeriodic is the same as scan for a periodic pattern.
+// It remembers the length of the prefix of the pattern matched after a shift by the period, so it's not compared again.
+func (p *twoWay[T]) scanPeriodic(text []T, overlap bool, yield func(i int) bool) {
+	m, n, ell := len(p.pattern), len(text), p.ell
+	memory := -1 // pattern[:memory+1] is known to match
+	for j := 0; j <= n-m; {
+		// Match the right part from left to right.
+		i := max(ell, memory) + 1
+		for i < m && p.pattern[i] == text[i+j] {
+			i++
+		}
+		if i < m {
+			j += i - ell
+			memory = -1
+			continue
+		}
+
+		// Match the left part from right to left.
+		i = ell
+		for i > memory && p.pattern[i] == text[i+j] {
+			i--
+		}
+		if i <= memory {
+			if !yield(j) {
+				return
+			}
+			if !overlap {
+				j += m
+				memory = -1
+				continue
+			}
+		}
+		j += p.per
+		memory = m - p.per - 1
+	}
+}
@@ -0,0 +1,129 @@
+package search
+
+import (
+	"cmp"
+	"math"
+)
+
+// invPhi is the inverse of the golden ratio.
+var invPhi = (math.Sqrt(5) - 1) / 2
+
+/*
+TernarySearchInt implements ternary search on the integers of the interval [lo, hi]. It returns the integer at which the unimodal function f is minimal,
+and the number of evaluations of f.
+The function f must be strictly decreasing, then constant on a (possibly one-element) plateau of minimums, then strictly increasing.
+If the plateau contains several integers, there is no guarantee which one will be returned.
+It evaluates f O(log(hi-lo)) times and never overflows, so the interval may span the whole range of int64.
+The interval must not be empty, that is lo <= hi.
+*/
+func TernarySearchInt[V cmp.Ordered](lo, hi int64, f func(int64) V) (int64, int) {
+	return ternarySearchInt(lo, hi, f, cmp.Less[V])
+}
+
+/*
+TernarySearchIntMax is the same as [TernarySearchInt], but returns the integer at which the unimodal function f is maximal.
+The function f must be strictly increasing, then constant on a plateau of maximums, then strictly decreasing.
+*/
+func TernarySearchIntMax[V cmp.Ordered](lo, hi int64, f func(int64) V) (int64, int) {
+	return ternarySearchInt(lo, hi, f, func(x, y V) bool { return cmp.Less(y, x) })
+}
+
+// ternarySearchInt implements TernarySearchInt for the order defined by the function less.
+func ternarySearchInt[V any](lo, hi int64, f func(int64) V, less func(x, y V) bool) (int64, int) {
+	evals := 0
+
+	for uint64(hi-lo) >= 3 {
+		// hi-lo may overflow int64, but not uint64.
+		d := int64(uint64(hi-lo) / 3)
+		m1, m2 := lo+d, hi-d
+		f1, f2 := f(m1), f(m2)
+		evals += 2
+
+		switch {
+		case less(f1, f2):
+			// m2 is after the plateau.
+			hi = m2 - 1
+		case less(f2, f1):
+			// m1 is before the plateau.
+			lo = m1 + 1
+		default:
+			// Either both are on the plateau, or m1 is before it and m2 is after it.
+			lo, hi = m1, m2
+		}
+	}
+
+	arg, v := lo, f(lo)
+	evals++
+	for i := lo; i != hi; {
+		// i <= hi would always be true for hi = math.MaxInt64.
+		i++
+		if fi := f(i); less(fi, v) {
+			arg, v = i, fi
+		}
+		evals++
+	}
+
+	return arg, evals
+}
+
+/*
+GoldenSectionSearch implements golden-section search on the interval [lo, hi]. It returns the approximation of the number at which the unimodal function f is minimal,
+and the number of evaluations of f.
+The function f must be strictly decreasing, then constant on a (possibly one-point) plateau of minimums, then strictly increasing.
+The search ends when the length of the interval containing the minimum is at most tol, or when it can't be narrowed in float64 precision.
+Each iteration narrows the interval by the golden ratio at the cost of a single evaluation of f,
+so it evaluates f about 1.44*log2((hi-lo)/tol) times.
+It never overflows, so the interval may span the whole range of float64.
+*/
+func GoldenSectionSearch(lo, hi float64, f func(float64) float64, tol float64) (float64, int) {
+	// The minimum is in [a, b], and a < c < d < b divide it in the golden ratio.
+	a, b := lo, hi
+	c, d := goldenSection(a, b)
+	fc, fd := f(c), f(d)
+	evals := 2
+
+	for b/2-a/2 > tol/2 {
+		if !(a < c && c < d && d < b) {
+			// The rounding errors of the reused points are amplified by each iteration,
+			// so they may end up out of order, then both are recomputed.
+			c, d = goldenSection(a, b)
+			if !(a < c && c < d && d < b) {
+				// The interval can't be narrowed in float64 precision.
+				break
+			}
+			fc, fd = f(c), f(d)
+			evals += 2
+		}
+
+		if fc < fd {
+			// The minimum is in [a, d], and c divides it in the golden ratio.
+			b, d, fd = d, c, fc
+			c, _ = goldenSection(a, b)
+			fc = f(c)
+		} else {
+			// The minimum is in [c, b], and d divides it in the golden ratio.
+			a, c, fc = c, d, fd
+			_, d = goldenSection(a, b)
+			fd = f(d)
+		}
+		evals++
+	}
+
+	mid, _ := midpoint(a, b)
+	return mid, evals
+}
+
+// goldenSection returns the points c < d dividing the interval [a, b] in the golden ratio.
+// Like [midpoint], it uses the half of the length of the interval, which doesn't overflow even if b-a does.
+func goldenSection(a, b float64) (c, d float64) {
+	h := invPhi * (b/2 - a/2)
+	return b - h - h, a + h + h
+}
+
+/*
+GoldenSectionSearchMax is the same as [GoldenSectionSearch], but returns the approximation of the number at which the unimodal function f is maximal.
+The function f must be strictly increasing, then constant on a plateau of maximums, then strictly decreasing.
+*/
+func GoldenSectionSearchMax(lo, hi float64, f func(float64) float64, tol float64) (float64, int) {
+	return GoldenSectionSearch(lo, hi, func(x float64) float64 { return -f(x) }, tol)
+}
@@ -0,0 +1,198 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTernarySearchInt(t *testing.T) {
+	parabola := func(m int64) func(int64) float64 {
+		return func(x int64) float64 {
+			d := float64(x) - float64(m)
+			return d * d
+		}
+	}
+	// plateau is minimal and constant on [l, r].
+	plateau := func(l, r int64) func(int64) float64 {
+		return func(x int64) float64 {
+			switch {
+			case x < l:
+				return float64(l) - float64(x)
+			case x > r:
+				return float64(x) - float64(r)
+			default:
+				return 0
+			}
+		}
+	}
+
+	tests := map[string]struct {
+		lo, hi int64
+		f      func(int64) float64
+		l, r   int64
+	}{
+		"[0, 0]":                 {0, 0, parabola(5), 0, 0},
+		"[0, 1]; 1":              {0, 1, parabola(5), 1, 1},
+		"[0, 2]; 0":              {0, 2, parabola(-5), 0, 0},
+		"[0, 99]; 37":            {0, 99, parabola(37), 37, 37},
+		"[0, 99]; 0":             {0, 99, parabola(0), 0, 0},
+		"[0, 99]; 99":            {0, 99, parabola(99), 99, 99},
+		"[-100, 100]; -3":        {-100, 100, parabola(-3), -3, -3},
+		"[0, 99]; plateau":       {0, 99, plateau(40, 60), 40, 60},
+		"[0, 99]; plateau at lo": {0, 99, plateau(0, 10), 0, 10},
+		"[0, 99]; plateau at hi": {0, 99, plateau(95, 99), 95, 99},
+		"[0, 99]; constant":      {0, 99, plateau(0, 99), 0, 99},
+		"[min, max]; 12345":      {math.MinInt64, math.MaxInt64, plateau(12345, 12345), 12345, 12345},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			evals := 0
+			f := func(x int64) float64 {
+				if x < tt.lo || x > tt.hi {
+					t.Fatalf("f(%d) out of range", x)
+				}
+				evals++
+				return tt.f(x)
+			}
+
+			arg, n := TernarySearchInt(tt.lo, tt.hi, f)
+
+			if arg < tt.l || arg > tt.r {
+				t.Errorf("got %d; want in [%d, %d]", arg, tt.l, tt.r)
+			}
+			if n != evals {
+				t.Errorf("reported %d evaluations; want %d", n, evals)
+			}
+			if width := float64(uint64(tt.hi - tt.lo)); float64(n) > 2*math.Log(width+1)/math.Log(1.5)+5 {
+				t.Errorf("%d evaluations; want O(log(%g))", n, width)
+			}
+		})
+	}
+}
+
+func TestTernarySearchInt_Int64(t *testing.T) {
+	// The values are exact int64s, so the function is strictly monotone on the whole range of int64.
+	for _, m := range []int64{math.MinInt64, -1, 0, 1 << 62, math.MaxInt64 - 1, math.MaxInt64} {
+		f := func(x int64) uint64 {
+			if x < m {
+				return uint64(m - x)
+			}
+			return uint64(x - m)
+		}
+
+		if arg, _ := TernarySearchInt(math.MinInt64, math.MaxInt64, f); arg != m {
+			t.Errorf("TernarySearchInt(min, max, |x-%d|) = %d; want %d", m, arg, m)
+		}
+		if arg, _ := TernarySearchIntMax(math.MinInt64, math.MaxInt64, func(x int64) int64 { return -int64(f(x) >> 1) }); f(arg) > 1 {
+			t.Errorf("TernarySearchIntMax(min, max, -|x-%d|/2) = %d; want within 1 of %d", m, arg, m)
+		}
+	}
+}
+
+func TestTernarySearchInt_Random(t *testing.T) {
+	for k := 0; k < 1000; k++ {
+		n := 1 + rand.Intn(100)
+		l := rand.Intn(n)
+		r := l + rand.Intn(n-l)
+
+		// Strictly decreasing on [0, l], constant on [l, r] and strictly increasing on [r, n).
+		v := make([]float64, n)
+		for i := l - 1; i >= 0; i-- {
+			v[i] = v[i+1] + 1 + float64(rand.Intn(10))
+		}
+		for i := r + 1; i < n; i++ {
+			v[i] = v[i-1] + 1 + float64(rand.Intn(10))
+		}
+
+		arg, _ := TernarySearchInt(0, int64(n-1), func(x int64) float64 { return v[x] })
+
+		if arg < int64(l) || arg > int64(r) {
+			t.Fatalf("TernarySearchInt(%v) = %d; want in [%d, %d]", v, arg, l, r)
+		}
+		if arg, _ := TernarySearchIntMax(0, int64(n-1), func(x int64) float64 { return -v[x] }); arg < int64(l) || arg > int64(r) {
+			t.Fatalf("TernarySearchIntMax(%v) = %d; want in [%d, %d]", v, arg, l, r)
+		}
+	}
+}
+
+func TestGoldenSectionSearch(t *testing.T) {
+	tests := map[string]struct {
+		lo, hi float64
+		f      func(float64) float64
+		l, r   float64
+	}{
+		"(x-2)^2":     {-10, 10, func(x float64) float64 { return (x - 2) * (x - 2) }, 2, 2},
+		"cos":         {0, 2 * math.Pi, math.Cos, math.Pi, math.Pi},
+		"|x+3|":       {-10, 10, func(x float64) float64 { return math.Abs(x + 3) }, -3, -3},
+		"at lo":       {0, 10, func(x float64) float64 { return x }, 0, 0},
+		"at hi":       {0, 10, func(x float64) float64 { return -x }, 10, 10},
+		"plateau":     {0, 10, func(x float64) float64 { return max(0, 3-x, x-4) }, 3, 4},
+		"empty":       {1, 1, func(x float64) float64 { return x }, 1, 1},
+		"wide":        {-1e300, 1e300, func(x float64) float64 { return math.Abs(x - 1e299) }, 1e299, 1e299},
+		"exponential": {-5, 5, func(x float64) float64 { return math.Exp(x) - 2*x }, math.Ln2, math.Ln2},
+	}
+
+	for name, tt := range tests {
+		for _, tol := range []float64{1e-3, 1e-6} {
+			t.Run(fmt.Sprintf("%s; %g", name, tol), func(t *testing.T) {
+				// The tolerance is relative to the length of the interval.
+				d := tol * max(1, tt.hi-tt.lo)
+				evals := 0
+				f := func(x float64) float64 {
+					evals++
+					return tt.f(x)
+				}
+
+				x, n := GoldenSectionSearch(tt.lo, tt.hi, f, d)
+
+				if x < tt.l-d || x > tt.r+d {
+					t.Errorf("got %g; want in [%g, %g] within %g", x, tt.l, tt.r, d)
+				}
+				if n != evals {
+					t.Errorf("reported %d evaluations; want %d", n, evals)
+				}
+				if want := 1.45*math.Log2(1/tol) + 3; float64(n) > want {
+					t.Errorf("%d evaluations; want at most %g", n, want)
+				}
+
+				if x, _ := GoldenSectionSearchMax(tt.lo, tt.hi, func(x float64) float64 { return -tt.f(x) }, d); x < tt.l-d || x > tt.r+d {
+					t.Errorf("GoldenSectionSearchMax got %g; want in [%g, %g] within %g", x, tt.l, tt.r, d)
+				}
+			})
+		}
+	}
+}
+
+func TestGoldenSectionSearch_ZeroTolerance(t *testing.T) {
+	x, n := GoldenSectionSearch(0, 4, func(x float64) float64 { return (x - math.Pi) * (x - math.Pi) }, 0)
+
+	if math.Abs(x-math.Pi) > 1e-7 {
+		t.Errorf("got %g; want %g", x, math.Pi)
+	}
+	if n > 200 {
+		t.Errorf("%d evaluations; want the search to stop at float64 precision", n)
+	}
+}
+
+func TestGoldenSectionSearch_ExtremeBounds(t *testing.T) {
+	tests := map[string]struct {
+		f    func(float64) float64
+		want float64
+	}{
+		"zero":     {func(x float64) float64 { return math.Abs(x) }, 0},
+		"positive": {func(x float64) float64 { return math.Abs(x - 1e307) }, 1e307},
+		"negative": {func(x float64) float64 { return math.Abs(x + 1e308) }, -1e308},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			x, _ := GoldenSectionSearch(-math.MaxFloat64, math.MaxFloat64, tt.f, 0)
+			if math.IsNaN(x) || math.Abs(x-tt.want) > 1e-9*math.Max(1, math.Abs(tt.want)) {
+				t.Errorf("got %g; want %g", x, tt.want)
+			}
+		})
+	}
+}
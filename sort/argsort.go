@@ -0,0 +1,145 @@
+package sort
+
+import "reflect"
+
+// ArgsortFunc returns the permutation of the indexes of the slice that sorts it using a custom comparison function,
+// without modifying the slice: the element with index perm[k] is the k-th element of the sorted slice.
+// The indexes are sorted using [PdqFunc].
+// The sort is not guaranteed to be stable.
+//
+// The same permutation is produced by sorting the indexes 0, 1, ..., len(s)-1 with any algorithm of the package
+// using the comparison function func(i, j int) int { return cmp(s[i], s[j]) }.
+func ArgsortFunc[S ~[]E, E any](s S, cmp func(a, b E) int) []int {
+	perm := identity(len(s))
+	PdqFunc(perm, func(i, j int) int {
+		return cmp(s[i], s[j])
+	})
+	return perm
+}
+
+// StableArgsortFunc is the same as [ArgsortFunc], but the indexes are sorted using [MergeFunc],
+// so that the indexes of equal elements are in increasing order.
+// The sort is stable.
+func StableArgsortFunc[S ~[]E, E any](s S, cmp func(a, b E) int) []int {
+	perm := identity(len(s))
+	MergeFunc(perm, func(i, j int) int {
+		return cmp(s[i], s[j])
+	})
+	return perm
+}
+
+// ApplyPermutation reorders the slice in place so that the element with index perm[k] is moved to index k,
+// e.g. applying the permutation returned by [ArgsortFunc] sorts the slice.
+// It follows the cycles of the permutation, so each element is moved once.
+// The permutation is temporarily modified, but restored before returning.
+// It panics if perm is not a permutation of the indexes of the slice.
+func ApplyPermutation[S ~[]E, E any](s S, perm []int) {
+	if len(perm) != len(s) {
+		panic("sort: invalid permutation")
+	}
+	applyPermutation(perm, func(i, j int) {
+		s[i], s[j] = s[j], s[i]
+	})
+}
+
+// InvertPermutation returns the inverse of the permutation: inv[perm[k]] = k.
+// For the permutation returned by [ArgsortFunc], inv[i] is the rank of the element with index i in the sorted slice.
+// It panics if perm is not a permutation.
+func InvertPermutation(perm []int) []int {
+	inv := make([]int, len(perm))
+	for i := range inv {
+		inv[i] = -1
+	}
+	for k, i := range perm {
+		if i < 0 || i >= len(perm) || inv[i] >= 0 {
+			panic("sort: invalid permutation")
+		}
+		inv[i] = k
+	}
+	return inv
+}
+
+// CoSortFunc stably sorts the keys using a custom comparison function and reorders each of the parallel slices the same way,
+// so that the elements with the same index in keys and the parallel slices stay together.
+// The slices are reordered in place following the cycles of the permutation returned by [StableArgsortFunc].
+// It panics if a parallel value is not a slice or its length differs from the length of keys.
+func CoSortFunc[S ~[]E, E any](keys S, cmp func(a, b E) int, parallel ...any) {
+	swaps := make([]func(i, j int), len(parallel))
+	for i, p := range parallel {
+		if reflect.ValueOf(p).Len() != len(keys) {
+			panic("sort: parallel slice length mismatch")
+		}
+		swaps[i] = reflect.Swapper(p)
+	}
+
+	perm := StableArgsortFunc(keys, cmp)
+	applyPermutation(perm, func(i, j int) {
+		keys[i], keys[j] = keys[j], keys[i]
+		for _, swap := range swaps {
+			swap(i, j)
+		}
+	})
+}
+
+// identity returns the identity permutation of n indexes.
+func identity(n int) []int {
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	return perm
+}
+
+// applyPermutation reorders a sequence using the swap function so that the element with index perm[k] is moved to index k.
+// The visited indexes are marked by complementing their entries in perm, which are restored before returning.
+// It panics if perm is not a permutation.
+func applyPermutation(perm []int, swap func(i, j int)) {
+	n := len(perm)
+
+	for _, i := range perm {
+		if i < 0 || i >= n {
+			panic("sort: invalid permutation")
+		}
+	}
+
+	// Validate that the indexes are distinct by marking the entries of the indexes the permutation maps to.
+	for k := 0; k < n; k++ {
+		i := perm[k]
+		if i < 0 {
+			i = ^i
+		}
+		if perm[i] < 0 {
+			restorePermutation(perm)
+			panic("sort: invalid permutation")
+		}
+		perm[i] = ^perm[i]
+	}
+	restorePermutation(perm)
+
+	for k := 0; k < n; k++ {
+		if perm[k] < 0 {
+			continue
+		}
+
+		// Swap along the cycle starting at k until it returns to k.
+		j := k
+		for perm[j] != k {
+			next := perm[j]
+			swap(j, next)
+			perm[j] = ^next
+			j = next
+		}
+		perm[j] = ^perm[j]
+	}
+
+	restorePermutation(perm)
+}
+
+// restorePermutation restores the entries of perm marked by complementing them.
+func restorePermutation(perm []int) {
+	for k, i := range perm {
+		if i < 0 {
+			perm[k] = ^i
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package sort_test
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+func TestArgsortFunc(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 10, 100, 10000} {
+		data := make([]int, n)
+		for i := range data {
+			data[i] = rand.Intn(n)
+		}
+		orig := slices.Clone(data)
+
+		perm := sort.ArgsortFunc(data, cmp.Compare[int])
+
+		if !slices.Equal(data, orig) {
+			t.Fatalf("ArgsortFunc modified the slice")
+		}
+		sorted := make([]int, n)
+		for k, i := range perm {
+			sorted[k] = data[i]
+		}
+		if !slices.IsSorted(sorted) {
+			t.Errorf("ArgsortFunc didn't sort %d ints", n)
+		}
+		testPermutation(t, perm, n)
+	}
+}
+
+func TestStableArgsortFunc(t *testing.T) {
+	n := 10000
+	data := make([]int, n)
+	for i := range data {
+		data[i] = rand.Intn(100)
+	}
+
+	perm := sort.StableArgsortFunc(data, cmp.Compare[int])
+
+	testPermutation(t, perm, n)
+	for k := 1; k < n; k++ {
+		i, j := perm[k-1], perm[k]
+		if data[i] > data[j] || data[i] == data[j] && i > j {
+			t.Fatalf("StableArgsortFunc isn't stable at %d: %d (%d), %d (%d)", k, i, data[i], j, data[j])
+		}
+	}
+}
+
+// testPermutation checks that perm is a permutation of n indexes.
+func testPermutation(t *testing.T, perm []int, n int) {
+	t.Helper()
+
+	if len(perm) != n {
+		t.Fatalf("len(perm) = %d; want %d", len(perm), n)
+	}
+	seen := make([]bool, n)
+	for _, i := range perm {
+		if i < 0 || i >= n || seen[i] {
+			t.Fatalf("%v is not a permutation", perm)
+		}
+		seen[i] = true
+	}
+}
+
+func TestApplyPermutation(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 10, 100, 10000} {
+		data := make([]int, n)
+		for i := range data {
+			data[i] = rand.Intn(n)
+		}
+		want := slices.Clone(data)
+		slices.SortStableFunc(want, cmp.Compare[int])
+
+		perm := sort.StableArgsortFunc(data, cmp.Compare[int])
+		orig := slices.Clone(perm)
+
+		sort.ApplyPermutation(data, perm)
+
+		if !slices.Equal(data, want) {
+			t.Errorf("ApplyPermutation didn't sort %d ints", n)
+		}
+		if !slices.Equal(perm, orig) {
+			t.Errorf("ApplyPermutation didn't restore the permutation")
+		}
+	}
+}
+
+func TestApplyPermutation_Any(t *testing.T) {
+	// The permutation produced by sorting the indexes with any algorithm can be applied.
+	data := []string{"d", "b", "e", "a", "c"}
+	perm := []int{0, 1, 2, 3, 4}
+	sort.HeapFunc(perm, func(i, j int) int { return cmp.Compare(data[i], data[j]) })
+
+	sort.ApplyPermutation(data, perm)
+
+	if want := []string{"a", "b", "c", "d", "e"}; !slices.Equal(data, want) {
+		t.Errorf("ApplyPermutation = %v; want %v", data, want)
+	}
+}
+
+func TestApplyPermutation_Invalid(t *testing.T) {
+	tests := [][]int{
+		{0, 1},
+		{0, 0, 1},
+		{2, 2, 0},
+		{0, 1, 3},
+		{0, -1, 2},
+	}
+	for _, perm := range tests {
+		func() {
+			orig := slices.Clone(perm)
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ApplyPermutation(%v) didn't panic", perm)
+				}
+				if !slices.Equal(perm, orig) {
+					t.Errorf("ApplyPermutation modified the invalid permutation %v to %v", orig, perm)
+				}
+			}()
+			sort.ApplyPermutation([]int{7, 8, 9}, perm)
+		}()
+	}
+}
+
+func TestInvertPermutation(t *testing.T) {
+	perm := rand.Perm(1000)
+	inv := sort.InvertPermutation(perm)
+	for k, i := range perm {
+		if inv[i] != k {
+			t.Fatalf("inv[%d] = %d; want %d", i, inv[i], k)
+		}
+	}
+	if inv2 := sort.InvertPermutation(inv); !slices.Equal(inv2, perm) {
+		t.Errorf("InvertPermutation isn't an involution")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("InvertPermutation didn't panic on an invalid permutation")
+		}
+	}()
+	sort.InvertPermutation([]int{1, 1})
+}
+
+func TestCoSortFunc(t *testing.T) {
+	n := 1000
+	keys := make([]int, n)
+	names := make([]string, n)
+	weights := make([]float64, n)
+	for i := range keys {
+		keys[i] = rand.Intn(50)
+		names[i] = strconv.Itoa(i)
+		weights[i] = float64(i)
+	}
+	orig := slices.Clone(keys)
+
+	sort.CoSortFunc(keys, cmp.Compare[int], names, weights)
+
+	if !slices.IsSorted(keys) {
+		t.Fatalf("CoSortFunc didn't sort the keys")
+	}
+	for k := range keys {
+		i, _ := strconv.Atoi(names[k])
+		if orig[i] != keys[k] || weights[k] != float64(i) {
+			t.Fatalf("CoSortFunc didn't keep the elements with index %d together", i)
+		}
+		if k > 0 && keys[k-1] == keys[k] && weights[k-1] > weights[k] {
+			t.Fatalf("CoSortFunc isn't stable at %d", k)
+		}
+	}
+}
+
+func TestCoSortFunc_LengthMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("CoSortFunc didn't panic on a length mismatch")
+		}
+	}()
+	sort.CoSortFunc([]int{2, 1}, cmp.Compare[int], []string{"a"})
+}
+
+func BenchmarkStableArgsortFuncRandom1M(b *testing.B) {
+	argsortFunc := func(s []int, cmp func(a, b int) int) {
+		sort.ApplyPermutation(s, sort.StableArgsortFunc(s, cmp))
+	}
+	benchmarkSortFuncRandom(b, 1<<20, argsortFunc, cmp.Compare[int])
+}
@@ -0,0 +1,78 @@
+package sort
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// BucketSortFunc implements bucket sort of elements by floating-point keys extracted using a key function.
+// The range of the keys is split into len(s) buckets of equal width, and each bucket is sorted using [InsertionFunc].
+// The key is extracted once per element.
+// NaN keys are ordered before any other keys, which is consistent with [cmp.Compare].
+// It runs in O(n) expected time if the keys are uniformly distributed, O(n^2) in the worst case,
+// and requires O(n) auxiliary space.
+// The sort is stable.
+func BucketSortFunc[S ~[]E, E any](s S, key func(E) float64) {
+	n := len(s)
+	if n < 2 {
+		return
+	}
+
+	type keyed struct {
+		key float64
+		v   E
+	}
+
+	// Find the range of the finite keys.
+	lo, hi := math.Inf(1), math.Inf(-1)
+	keys := make([]keyed, n)
+	for i, v := range s {
+		k := key(v)
+		keys[i] = keyed{k, v}
+		if !math.IsInf(k, 0) {
+			lo, hi = min(lo, k), max(hi, k)
+		}
+	}
+
+	// The keys are halved so that the width of the range doesn't overflow.
+	// NaNs and infinities are clamped to the first and the last bucket.
+	scale := float64(n) / (hi/2 - lo/2)
+	bucket := func(k float64) int {
+		b := (k/2 - lo/2) * scale
+		switch {
+		case b != b || b < 0:
+			return 0
+		case b >= float64(n):
+			return n - 1
+		default:
+			return int(b)
+		}
+	}
+
+	// Distribute the elements into the buckets, preserving their order.
+	starts := make([]int, n+1)
+	for _, e := range keys {
+		starts[bucket(e.key)+1]++
+	}
+	for b := 1; b <= n; b++ {
+		starts[b] += starts[b-1]
+	}
+
+	aux := make([]keyed, n)
+	next := slices.Clone(starts[:n])
+	for _, e := range keys {
+		b := bucket(e.key)
+		aux[next[b]] = e
+		next[b]++
+	}
+
+	cmpKeys := func(x, y keyed) int { return cmp.Compare(x.key, y.key) }
+	for b := 0; b < n; b++ {
+		InsertionFunc(aux[starts[b]:starts[b+1]], cmpKeys)
+	}
+
+	for i, e := range aux {
+		s[i] = e.v
+	}
+}
@@ -0,0 +1,93 @@
+package sort_test
+
+import (
+	"cmp"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+func TestBucketSortFunc(t *testing.T) {
+	gens := map[string]func() float64{
+		"uniform":     rand.Float64,
+		"normal":      rand.NormFloat64,
+		"exponential": rand.ExpFloat64,
+		"few unique":  func() float64 { return float64(rand.Intn(4)) },
+		"huge": func() float64 {
+			return []float64{-math.MaxFloat64, math.MaxFloat64, 0}[rand.Intn(3)]
+		},
+		"special": func() float64 {
+			return []float64{math.NaN(), math.Inf(1), math.Inf(-1), math.Copysign(0, -1), 0, 1, -1}[rand.Intn(7)]
+		},
+	}
+	for name, gen := range gens {
+		t.Run(name, func(t *testing.T) {
+			for _, n := range []int{0, 1, 2, 10, 100, 10000} {
+				data := make([]float64, n)
+				for i := range data {
+					data[i] = gen()
+				}
+				want := slices.Clone(data)
+				slices.SortStableFunc(want, cmp.Compare[float64])
+
+				sort.BucketSortFunc(data, func(v float64) float64 { return v })
+
+				if !slices.EqualFunc(data, want, func(a, b float64) bool { return cmp.Compare(a, b) == 0 }) {
+					t.Errorf("BucketSortFunc didn't sort %d floats", n)
+				}
+			}
+		})
+	}
+}
+
+func TestBucketSortFunc_Stability(t *testing.T) {
+	n, m := 100000, 1000
+	if testing.Short() {
+		n, m = 1000, 100
+	}
+
+	bucketSortFunc := func(s intPairs, _ func(a, b intPair) int) {
+		sort.BucketSortFunc(s, func(p intPair) float64 { return float64(p.a) })
+	}
+	testSortFuncStability(t, bucketSortFunc, n, m)
+}
+
+func TestBucketSortFunc_Patterns(t *testing.T) {
+	bucketSortFunc := func(s []int, _ func(a, b int) int) {
+		sort.BucketSortFunc(s, func(v int) float64 { return float64(v) })
+	}
+	testSortFuncPatterns(t, bucketSortFunc, cmp.Compare[int])
+}
+
+func BenchmarkBucketSortFuncUniform1M(b *testing.B) {
+	b.StopTimer()
+	data := make([]float64, 1<<20)
+	for i := range data {
+		data[i] = rand.Float64()
+	}
+	s := make([]float64, len(data))
+	for i := 0; i < b.N; i++ {
+		copy(s, data)
+		b.StartTimer()
+		sort.BucketSortFunc(s, func(v float64) float64 { return v })
+		b.StopTimer()
+	}
+}
+
+func BenchmarkSlicesSortFloatsUniform1M(b *testing.B) {
+	b.StopTimer()
+	data := make([]float64, 1<<20)
+	for i := range data {
+		data[i] = rand.Float64()
+	}
+	s := make([]float64, len(data))
+	for i := 0; i < b.N; i++ {
+		copy(s, data)
+		b.StartTimer()
+		slices.Sort(s)
+		b.StopTimer()
+	}
+}
@@ -0,0 +1,67 @@
+package sort
+
+// SortByKeyFunc sorts the slice by keys extracted using a key function and compared using a custom comparison function.
+// The key is extracted once per element, which matters when the key is expensive to compute,
+// e.g. requires parsing or normalization (the Schwartzian transform).
+// The key-index pairs are sorted using [PdqFunc], and then the elements are permuted in place following the cycles of the permutation.
+// It performs O(n*log(n)) comparisons, O(n) key extractions and moves, and requires O(n) auxiliary space for the pairs.
+// The sort is not guaranteed to be stable.
+func SortByKeyFunc[S ~[]E, E, K any](s S, key func(E) K, cmp func(a, b K) int) {
+	pairs := keyIndexes(s, key)
+	PdqFunc(pairs, func(a, b keyIndex[K]) int {
+		return cmp(a.key, b.key)
+	})
+	permute(s, pairs)
+}
+
+// StableSortByKeyFunc is the same as [SortByKeyFunc], but the sort is stable:
+// the pairs of equal keys are ordered by their original indexes.
+func StableSortByKeyFunc[S ~[]E, E, K any](s S, key func(E) K, cmp func(a, b K) int) {
+	pairs := keyIndexes(s, key)
+	PdqFunc(pairs, func(a, b keyIndex[K]) int {
+		if c := cmp(a.key, b.key); c != 0 {
+			return c
+		}
+		return a.i - b.i
+	})
+	permute(s, pairs)
+}
+
+// keyIndex is a key of the element with index i.
+type keyIndex[K any] struct {
+	key K
+	i   int
+}
+
+// keyIndexes returns the keys of the elements of s paired with their indexes.
+func keyIndexes[E, K any](s []E, key func(E) K) []keyIndex[K] {
+	pairs := make([]keyIndex[K], len(s))
+	for i, v := range s {
+		pairs[i] = keyIndex[K]{key(v), i}
+	}
+	return pairs
+}
+
+// permute reorders s in place so that the element with index pairs[k].i is moved to index k.
+// It follows the cycles of the permutation, moving each element once, and marks the visited indexes in pairs.
+func permute[E, K any](s []E, pairs []keyIndex[K]) {
+	for k := range pairs {
+		if pairs[k].i == k {
+			continue
+		}
+
+		// Rotate the cycle starting at k: s[k] = s[pairs[k].i], and so on, until the cycle returns to k.
+		v := s[k]
+		j := k
+		for {
+			next := pairs[j].i
+			pairs[j].i = j
+			if next == k {
+				s[j] = v
+				break
+			}
+			s[j] = s[next]
+			j = next
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package sort_test
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+func TestSortByKeyFunc(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 10, 100, 10000} {
+		data := make([]string, n)
+		for i := range data {
+			data[i] = strconv.Itoa(rand.Intn(n) - n/2)
+		}
+		want := slices.Clone(data)
+		slices.SortStableFunc(want, func(a, b string) int {
+			x, _ := strconv.Atoi(a)
+			y, _ := strconv.Atoi(b)
+			return cmp.Compare(x, y)
+		})
+
+		calls := 0
+		sort.SortByKeyFunc(data, func(s string) int {
+			calls++
+			v, _ := strconv.Atoi(s)
+			return v
+		}, cmp.Compare[int])
+
+		if !slices.Equal(data, want) {
+			t.Errorf("SortByKeyFunc didn't sort %d numeric strings", n)
+		}
+		if calls != n {
+			t.Errorf("SortByKeyFunc extracted the key %d times; want %d", calls, n)
+		}
+	}
+}
+
+func TestSortByKeyFunc_Patterns(t *testing.T) {
+	sortByKeyFunc := func(s []int, cmp func(a, b int) int) {
+		sort.SortByKeyFunc(s, func(v int) int { return v }, cmp)
+	}
+	testSortFuncPatterns(t, sortByKeyFunc, cmp.Compare[int])
+}
+
+func TestStableSortByKeyFunc_Stability(t *testing.T) {
+	n, m := 100000, 1000
+	if testing.Short() {
+		n, m = 1000, 100
+	}
+
+	stableSortByKeyFunc := func(s intPairs, _ func(a, b intPair) int) {
+		sort.StableSortByKeyFunc(s, func(p intPair) int { return p.a }, cmp.Compare[int])
+	}
+	testSortFuncStability(t, stableSortByKeyFunc, n, m)
+}
+
+func TestStableSortByKeyFunc_Strings(t *testing.T) {
+	data := []string{"b", "A", "a", "C", "B", "c", "a", "A"}
+	want := slices.Clone(data)
+	slices.SortStableFunc(want, func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) })
+
+	sort.StableSortByKeyFunc(data, strings.ToLower, strings.Compare)
+
+	if !slices.Equal(data, want) {
+		t.Errorf("StableSortByKeyFunc = %v; want %v", data, want)
+	}
+}
+
+// expensiveKey is a key that requires parsing the element.
+func expensiveKey(s string) int {
+	v, _ := strconv.Atoi(strings.TrimSpace(strings.ToLower(s)))
+	return v
+}
+
+func benchmarkSortByKey(b *testing.B, sortFunc func([]string)) {
+	b.StopTimer()
+	data := make([]string, 1<<16)
+	for i := range data {
+		data[i] = " " + strconv.Itoa(rand.Int()) + " "
+	}
+	s := make([]string, len(data))
+	for i := 0; i < b.N; i++ {
+		copy(s, data)
+		b.StartTimer()
+		sortFunc(s)
+		b.StopTimer()
+	}
+}
+
+func BenchmarkSortByKeyFunc64K(b *testing.B) {
+	benchmarkSortByKey(b, func(s []string) { sort.SortByKeyFunc(s, expensiveKey, cmp.Compare[int]) })
+}
+
+func BenchmarkStableSortByKeyFunc64K(b *testing.B) {
+	benchmarkSortByKey(b, func(s []string) { sort.StableSortByKeyFunc(s, expensiveKey, cmp.Compare[int]) })
+}
+
+func BenchmarkPdqFuncExpensiveKey64K(b *testing.B) {
+	benchmarkSortByKey(b, func(s []string) {
+		sort.PdqFunc(s, func(a, b string) int { return cmp.Compare(expensiveKey(a), expensiveKey(b)) })
+	})
+}
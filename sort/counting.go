@@ -0,0 +1,42 @@
+package sort
+
+// CountingSortFunc implements counting sort of elements by small integer keys in [0, k) extracted using a key function.
+// The key is extracted once per element.
+// It runs in O(n+k) time and requires O(n+k) auxiliary space.
+// It panics if k <= 0 or a key is out of range, even if the slice has fewer than two elements.
+// The sort is stable.
+func CountingSortFunc[S ~[]E, E any](s S, k int, key func(E) int) {
+	if k <= 0 {
+		panic("sort: invalid key range")
+	}
+	n := len(s)
+	keys := make([]int, n)
+	for i, v := range s {
+		c := key(v)
+		if c < 0 || c >= k {
+			panic("sort: key out of range")
+		}
+		keys[i] = c
+	}
+	if n < 2 {
+		return
+	}
+
+	counts := make([]int, k+1)
+	for _, c := range keys {
+		counts[c+1]++
+	}
+
+	// Turn the counts into the starting indexes.
+	for c := 1; c <= k; c++ {
+		counts[c] += counts[c-1]
+	}
+
+	aux := make([]E, n)
+	for i, v := range s {
+		c := keys[i]
+		aux[counts[c]] = v
+		counts[c]++
+	}
+	copy(s, aux)
+}
@@ -0,0 +1,94 @@
+package sort_test
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+func TestCountingSortFunc(t *testing.T) {
+	for _, k := range []int{1, 2, 10, 1000} {
+		for _, n := range []int{0, 1, 2, 10, 100, 10000} {
+			data := make([]int, n)
+			for i := range data {
+				data[i] = rand.Intn(k)
+			}
+			want := slices.Clone(data)
+			slices.Sort(want)
+
+			sort.CountingSortFunc(data, k, func(v int) int { return v })
+
+			if !slices.Equal(data, want) {
+				t.Errorf("CountingSortFunc didn't sort %d ints in [0, %d)", n, k)
+			}
+		}
+	}
+}
+
+func TestCountingSortFunc_Stability(t *testing.T) {
+	n, m := 100000, 1000
+	if testing.Short() {
+		n, m = 1000, 100
+	}
+
+	countingSortFunc := func(s intPairs, _ func(a, b intPair) int) {
+		sort.CountingSortFunc(s, len(s)+1, func(p intPair) int { return p.a })
+	}
+	testSortFuncStability(t, countingSortFunc, n, m)
+}
+
+func TestCountingSortFunc_OutOfRange(t *testing.T) {
+	for _, v := range []int{-1, 10} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("CountingSortFunc didn't panic on key %d", v)
+				}
+			}()
+			sort.CountingSortFunc([]int{0, v}, 10, func(v int) int { return v })
+		}()
+	}
+}
+
+func TestCountingSortFunc_OutOfRangeShort(t *testing.T) {
+	tests := map[string]func(){
+		"one element": func() { sort.CountingSortFunc([]int{10}, 10, func(v int) int { return v }) },
+		"k = 0":       func() { sort.CountingSortFunc([]int{}, 0, func(v int) int { return v }) },
+		"k < 0":       func() { sort.CountingSortFunc([]int{0, 0}, -1, func(v int) int { return v }) },
+	}
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("CountingSortFunc didn't panic")
+				}
+			}()
+			f()
+		})
+	}
+}
+
+func BenchmarkCountingSortFuncRandom1M(b *testing.B) {
+	countingSortFunc := func(s []int, _ func(a, b int) int) {
+		sort.CountingSortFunc(s, 1<<20, func(v int) int { return v & (1<<20 - 1) })
+	}
+	benchmarkSortFuncRandom(b, 1<<20, countingSortFunc, cmp.Compare[int])
+}
+
+func BenchmarkCountingSortFuncSmallKeys1M(b *testing.B) {
+	b.StopTimer()
+	data := make([]int, 1<<20)
+	for i := range data {
+		data[i] = rand.Intn(16)
+	}
+	s := make([]int, len(data))
+	for i := 0; i < b.N; i++ {
+		copy(s, data)
+		b.StartTimer()
+		sort.CountingSortFunc(s, 16, func(v int) int { return v })
+		b.StopTimer()
+	}
+}
@@ -0,0 +1,127 @@
+package external
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Decoder decodes records from an input stream.
+type Decoder[T any] interface {
+	// Decode returns the next record.
+	// It returns io.EOF if there are no more records.
+	Decode() (T, error)
+}
+
+// Encoder encodes records to an output stream.
+type Encoder[T any] interface {
+	// Encode writes the record to the stream.
+	Encode(v T) error
+}
+
+// Codec creates encoders and decoders of records.
+// Writers passed to NewEncoder are already buffered and flushed by the caller, so encoders should write directly to them.
+// The encoding of a record must not depend on the records encoded before it, since the encoded records are reordered by the sort.
+type Codec[T any] interface {
+	NewDecoder(r io.Reader) Decoder[T]
+	NewEncoder(w io.Writer) Encoder[T]
+}
+
+// Lines is a codec of newline-delimited text records.
+// The trailing newline is stripped from the decoded records; the last record may lack one.
+type Lines struct{}
+
+// NewDecoder returns a decoder of newline-delimited records.
+func (Lines) NewDecoder(r io.Reader) Decoder[string] {
+	return &linesDecoder{r: bufio.NewReader(r)}
+}
+
+// NewEncoder returns an encoder of newline-delimited records.
+func (Lines) NewEncoder(w io.Writer) Encoder[string] {
+	return &linesEncoder{w: w}
+}
+
+type linesDecoder struct {
+	r *bufio.Reader
+}
+
+func (d *linesDecoder) Decode() (string, error) {
+	s, err := d.r.ReadString('\n')
+	if err == io.EOF && s != "" {
+		return s, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(s, "\n"), nil
+}
+
+type linesEncoder struct {
+	w io.Writer
+}
+
+func (e *linesEncoder) Encode(v string) error {
+	if _, err := io.WriteString(e.w, v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(e.w, "\n")
+	return err
+}
+
+// LengthPrefixed is a codec of binary records, each prefixed with its length encoded as an unsigned varint.
+type LengthPrefixed struct{}
+
+// ErrRecordTooLarge is returned when decoding a length-prefixed record whose length exceeds [MaxRecordLength].
+var ErrRecordTooLarge = errors.New("external: record too large")
+
+// MaxRecordLength is the maximum length of a length-prefixed record.
+const MaxRecordLength = 1 << 30
+
+// NewDecoder returns a decoder of length-prefixed records.
+func (LengthPrefixed) NewDecoder(r io.Reader) Decoder[[]byte] {
+	return &lengthPrefixedDecoder{r: bufio.NewReader(r)}
+}
+
+// NewEncoder returns an encoder of length-prefixed records.
+func (LengthPrefixed) NewEncoder(w io.Writer) Encoder[[]byte] {
+	return &lengthPrefixedEncoder{w: w}
+}
+
+type lengthPrefixedDecoder struct {
+	r *bufio.Reader
+}
+
+func (d *lengthPrefixedDecoder) Decode() ([]byte, error) {
+	n, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, err
+	}
+	if n > MaxRecordLength {
+		return nil, ErrRecordTooLarge
+	}
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(d.r, b); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+type lengthPrefixedEncoder struct {
+	w   io.Writer
+	buf [binary.MaxVarintLen64]byte
+}
+
+func (e *lengthPrefixedEncoder) Encode(v []byte) error {
+	n := binary.PutUvarint(e.buf[:], uint64(len(v)))
+	if _, err := e.w.Write(e.buf[:n]); err != nil {
+		return err
+	}
+	_, err := e.w.Write(v)
+	return err
+}
@@ -0,0 +1,92 @@
+package external_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort/external"
+)
+
+func TestLines(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want []string
+	}{
+		"empty":            {"", nil},
+		"single":           {"a\n", []string{"a"}},
+		"no final newline": {"a\nb", []string{"a", "b"}},
+		"empty lines":      {"\n\na\n", []string{"", "", "a"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dec := external.Lines{}.NewDecoder(bytes.NewBufferString(tt.in))
+
+			var got []string
+			for {
+				v, err := dec.Decode()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("got unexpected error: %v", err)
+				}
+				got = append(got, v)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLengthPrefixed(t *testing.T) {
+	records := [][]byte{{}, []byte("a"), bytes.Repeat([]byte{0, '\n', 0xff}, 1000)}
+
+	var buf bytes.Buffer
+	enc := external.LengthPrefixed{}.NewEncoder(&buf)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			t.Fatalf("got unexpected error: %v", err)
+		}
+	}
+
+	dec := external.LengthPrefixed{}.NewDecoder(&buf)
+	for _, want := range records {
+		got, err := dec.Decode()
+		if err != nil {
+			t.Fatalf("got unexpected error: %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("got %q; want %q", got, want)
+		}
+	}
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("got %v; want %v", err, io.EOF)
+	}
+}
+
+func TestLengthPrefixed_Truncated(t *testing.T) {
+	tests := map[string]struct {
+		in   []byte
+		want error
+	}{
+		"truncated record": {[]byte{3, 'a'}, io.ErrUnexpectedEOF},
+		"truncated length": {[]byte{0x80}, io.ErrUnexpectedEOF},
+		"too large":        {[]byte{0xff, 0xff, 0xff, 0xff, 0x0f}, external.ErrRecordTooLarge},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := external.LengthPrefixed{}.NewDecoder(bytes.NewReader(tt.in)).Decode()
+
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got %v; want %v", err, tt.want)
+			}
+		})
+	}
+}
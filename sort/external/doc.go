@@ -0,0 +1,2 @@
+// Package external provides external sorting of data sets that don't fit in memory.
+package external
@@ -0,0 +1,303 @@
+package external
+
+import (
+	"bufio"
+	"cmp"
+	"container/heap"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+const (
+	// DefaultChunkSize is the default maximum number of records sorted in memory at once.
+	DefaultChunkSize = 1 << 20
+	// DefaultChunkBytes is the default maximum total encoded size in bytes of the records sorted in memory at once.
+	DefaultChunkBytes = 256 << 20
+	// DefaultFanIn is the default maximum number of runs merged at once.
+	DefaultFanIn = 64
+)
+
+// Options configures an external sort.
+// The zero value is ready to use.
+type Options struct {
+	// ChunkSize is the maximum number of records sorted in memory at once.
+	// If ChunkSize <= 0, DefaultChunkSize is used.
+	ChunkSize int
+	// ChunkBytes is the maximum total encoded size in bytes of the records sorted in memory at once,
+	// which bounds the memory used for variable-size records, up to the overhead of their in-memory representation.
+	// The encoded records are kept in memory along with the decoded ones, so they're written without encoding them again.
+	// A chunk always holds at least one record, even if it's larger.
+	// If ChunkBytes <= 0, DefaultChunkBytes is used.
+	ChunkBytes int
+	// FanIn is the maximum number of runs merged at once, which bounds the number of open temporary files.
+	// If there are more runs, they are merged in several passes.
+	// If FanIn < 2, DefaultFanIn is used.
+	FanIn int
+	// TempDir is the directory for the temporary files holding the sorted runs.
+	// If TempDir is empty, the default directory for temporary files is used.
+	TempDir string
+}
+
+// Sort implements external merge sort of records read from src and written to dst using the codec.
+// It is like [SortFunc], but compares records using [cmp.Compare].
+func Sort[T cmp.Ordered](dst io.Writer, src io.Reader, codec Codec[T], opts *Options) error {
+	return SortFunc(dst, src, codec, cmp.Compare[T], opts)
+}
+
+// SortFunc implements external merge sort of records read from src and written to dst using the codec and a custom comparison function.
+// The input is split into chunks of at most opts.ChunkSize records and opts.ChunkBytes encoded bytes, each sorted in memory using [sort.MergeFunc]
+// and spilled to a temporary file as a sorted run. The runs are then k-way merged into dst.
+// If the input fits in a single chunk, it's sorted in memory without using temporary files.
+// The temporary files are removed before SortFunc returns.
+// If opts is nil, the default options are used.
+// The sort is stable.
+func SortFunc[T any](dst io.Writer, src io.Reader, codec Codec[T], cmp func(a, b T) int, opts *Options) (err error) {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.ChunkSize <= 0 {
+		o.ChunkSize = DefaultChunkSize
+	}
+	if o.ChunkBytes <= 0 {
+		o.ChunkBytes = DefaultChunkBytes
+	}
+	if o.FanIn < 2 {
+		o.FanIn = DefaultFanIn
+	}
+
+	var runs []string // names of the temporary files holding the sorted runs
+	defer func() {
+		err = errors.Join(err, removeRuns(runs))
+	}()
+
+	dec := codec.NewDecoder(bufio.NewReader(src))
+	// The records of a chunk are encoded once when they're read, and the encoded records are written in the sorted order.
+	var buf appendWriter
+	enc := codec.NewEncoder(&buf)
+	chunk := make([]record[T], 0, min(o.ChunkSize, 1<<16))
+	// The record read after a full chunk starts the next one.
+	var next record[T]
+	hasNext := false
+
+	for eof := false; !eof; {
+		chunk = chunk[:0]
+		if hasNext {
+			// The next record is the last one encoded to the buffer, so it's moved to its start.
+			buf = buf[:copy(buf, buf[next.start:next.end])]
+			chunk = append(chunk, record[T]{v: next.v, end: len(buf)})
+			hasNext = false
+		} else {
+			buf = buf[:0]
+		}
+		for {
+			v, err := dec.Decode()
+			if err == io.EOF {
+				eof = true
+				break
+			}
+			if err != nil {
+				return err
+			}
+
+			start := len(buf)
+			if err := enc.Encode(v); err != nil {
+				return err
+			}
+			r := record[T]{v: v, start: start, end: len(buf)}
+			// The chunk is spilled only if a record follows it, so the input of a single full chunk is sorted in memory.
+			if len(chunk) == o.ChunkSize || len(chunk) > 0 && len(buf) > o.ChunkBytes {
+				next, hasNext = r, true
+				break
+			}
+			chunk = append(chunk, r)
+		}
+
+		sort.MergeFunc(chunk, func(a, b record[T]) int {
+			return cmp(a.v, b.v)
+		})
+
+		// The whole input fits in memory.
+		if eof && len(runs) == 0 {
+			return writeChunk(dst, buf, chunk)
+		}
+		if len(chunk) == 0 {
+			break
+		}
+
+		name, err := spill(o.TempDir, func(w io.Writer) error {
+			return writeChunk(w, buf, chunk)
+		})
+		if name != "" {
+			runs = append(runs, name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Merge the runs in passes until they can be merged into dst at once.
+	// Runs are merged in order, so that the merge is stable.
+	for len(runs) > o.FanIn {
+		var merged []string
+		for i := 0; i < len(runs); i += o.FanIn {
+			group := runs[i:min(i+o.FanIn, len(runs))]
+
+			name, err := spill(o.TempDir, func(w io.Writer) error {
+				return mergeRuns(w, codec, cmp, group)
+			})
+			if name != "" {
+				merged = append(merged, name)
+			}
+			if err != nil {
+				return errors.Join(err, removeRuns(merged))
+			}
+		}
+
+		err := removeRuns(runs)
+		runs = merged
+		if err != nil {
+			return err
+		}
+	}
+
+	return mergeRuns(dst, codec, cmp, runs)
+}
+
+// record is a record of a chunk along with the range of its encoding in the buffer of the chunk.
+type record[T any] struct {
+	v          T
+	start, end int
+}
+
+// appendWriter is a writer appending the bytes written to it to the slice.
+type appendWriter []byte
+
+func (w *appendWriter) Write(p []byte) (int, error) {
+	*w = append(*w, p...)
+	return len(p), nil
+}
+
+// spill creates a temporary file in dir, writes to it using the write function and closes it.
+// It returns the name of the file, if it was created, even if an error occurred.
+func spill(dir string, write func(w io.Writer) error) (name string, err error) {
+	f, err := os.CreateTemp(dir, "extsort-*")
+	if err != nil {
+		return "", err
+	}
+	if err := write(f); err != nil {
+		return f.Name(), errors.Join(err, f.Close())
+	}
+	return f.Name(), f.Close()
+}
+
+// writeChunk writes the encoded records of the chunk stored in buf to w.
+func writeChunk[T any](w io.Writer, buf []byte, chunk []record[T]) error {
+	bw := bufio.NewWriter(w)
+	for _, r := range chunk {
+		if _, err := bw.Write(buf[r.start:r.end]); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// mergeRuns k-way merges the sorted runs into w using the codec.
+// Equal records are taken from the runs in order.
+func mergeRuns[T any](w io.Writer, codec Codec[T], cmp func(a, b T) int, runs []string) (err error) {
+	h := &runHeap[T]{cmp: cmp}
+
+	var files []*os.File
+	defer func() {
+		for _, f := range files {
+			err = errors.Join(err, f.Close())
+		}
+	}()
+
+	for i, name := range runs {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+
+		dec := codec.NewDecoder(bufio.NewReader(f))
+		v, err := dec.Decode()
+		if err == io.EOF {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		h.runs = append(h.runs, &run[T]{dec: dec, head: v, idx: i})
+	}
+	heap.Init(h)
+
+	bw := bufio.NewWriter(w)
+	enc := codec.NewEncoder(bw)
+
+	for h.Len() > 0 {
+		r := h.runs[0]
+		if err := enc.Encode(r.head); err != nil {
+			return err
+		}
+
+		v, err := r.dec.Decode()
+		switch {
+		case err == io.EOF:
+			heap.Pop(h)
+		case err != nil:
+			return err
+		default:
+			r.head = v
+			heap.Fix(h, 0)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// removeRuns removes the temporary files of the runs.
+func removeRuns(runs []string) error {
+	var errs []error
+	for _, name := range runs {
+		errs = append(errs, os.Remove(name))
+	}
+	return errors.Join(errs...)
+}
+
+// run is a sorted run being merged.
+type run[T any] struct {
+	dec  Decoder[T]
+	head T   // next record of the run
+	idx  int // index of the run, used to break ties
+}
+
+// runHeap is a min-heap of runs ordered by their next records.
+type runHeap[T any] struct {
+	runs []*run[T]
+	cmp  func(a, b T) int
+}
+
+func (h *runHeap[T]) Len() int { return len(h.runs) }
+
+func (h *runHeap[T]) Less(i, j int) bool {
+	if c := h.cmp(h.runs[i].head, h.runs[j].head); c != 0 {
+		return c < 0
+	}
+	return h.runs[i].idx < h.runs[j].idx
+}
+
+func (h *runHeap[T]) Swap(i, j int) { h.runs[i], h.runs[j] = h.runs[j], h.runs[i] }
+
+func (h *runHeap[T]) Push(x any) { h.runs = append(h.runs, x.(*run[T])) }
+
+func (h *runHeap[T]) Pop() any {
+	n := len(h.runs)
+	r := h.runs[n-1]
+	h.runs = h.runs[:n-1]
+	return r
+}
@@ -0,0 +1,282 @@
+package external_test
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort/external"
+)
+
+func TestSort_Lines(t *testing.T) {
+	tests := []struct {
+		n    int
+		opts external.Options
+	}{
+		{0, external.Options{}},
+		{1, external.Options{}},
+		{1000, external.Options{}},
+		{1000, external.Options{ChunkSize: 1000}},
+		{1000, external.Options{ChunkSize: 100}},
+		{1000, external.Options{ChunkSize: 7, FanIn: 2}},
+		{1000, external.Options{ChunkSize: 10, FanIn: 3}},
+		{1000, external.Options{ChunkBytes: 100}},
+		{1000, external.Options{ChunkBytes: 1, FanIn: 8}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("n=%d,chunk=%d,bytes=%d,fanin=%d", tt.n, tt.opts.ChunkSize, tt.opts.ChunkBytes, tt.opts.FanIn), func(t *testing.T) {
+			tt.opts.TempDir = t.TempDir()
+
+			lines := make([]string, tt.n)
+			for i := range lines {
+				lines[i] = fmt.Sprint(rand.Intn(tt.n))
+			}
+			var src bytes.Buffer
+			for _, l := range lines {
+				src.WriteString(l + "\n")
+			}
+
+			var dst bytes.Buffer
+			if err := external.Sort(&dst, &src, external.Lines{}, &tt.opts); err != nil {
+				t.Fatalf("got unexpected error: %v", err)
+			}
+
+			slices.Sort(lines)
+			want := strings.Join(lines, "\n")
+			if tt.n > 0 {
+				want += "\n"
+			}
+			if got := dst.String(); got != want {
+				t.Errorf("got %q; want %q", got, want)
+			}
+
+			if files, _ := os.ReadDir(tt.opts.TempDir); len(files) != 0 {
+				t.Errorf("got %d temporary files left; want 0", len(files))
+			}
+		})
+	}
+}
+
+// pairCodec encodes pairs of uint32 as 8 bytes.
+type pairCodec struct{}
+
+type pair struct {
+	a, b uint32
+}
+
+type pairDecoder struct{ r io.Reader }
+
+type pairEncoder struct{ w io.Writer }
+
+func (pairCodec) NewDecoder(r io.Reader) external.Decoder[pair] { return pairDecoder{r} }
+
+func (pairCodec) NewEncoder(w io.Writer) external.Encoder[pair] { return pairEncoder{w} }
+
+func (d pairDecoder) Decode() (pair, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(d.r, b[:]); err != nil {
+		return pair{}, err
+	}
+	return pair{binary.BigEndian.Uint32(b[:4]), binary.BigEndian.Uint32(b[4:])}, nil
+}
+
+func (e pairEncoder) Encode(p pair) error {
+	var b [8]byte
+	binary.BigEndian.PutUint32(b[:4], p.a)
+	binary.BigEndian.PutUint32(b[4:], p.b)
+	_, err := e.w.Write(b[:])
+	return err
+}
+
+func TestSortFunc_Stability(t *testing.T) {
+	n, m := 10000, 100
+
+	var src bytes.Buffer
+	enc := pairCodec{}.NewEncoder(&src)
+	for i := 0; i < n; i++ {
+		if err := enc.Encode(pair{uint32(rand.Intn(m)), uint32(i)}); err != nil {
+			t.Fatalf("got unexpected error: %v", err)
+		}
+	}
+
+	var dst bytes.Buffer
+	cmpA := func(x, y pair) int { return cmp.Compare(x.a, y.a) }
+	opts := &external.Options{ChunkSize: 64, FanIn: 4, TempDir: t.TempDir()}
+	if err := external.SortFunc(&dst, &src, pairCodec{}, cmpA, opts); err != nil {
+		t.Fatalf("got unexpected error: %v", err)
+	}
+
+	dec := pairCodec{}.NewDecoder(&dst)
+	var got []pair
+	for {
+		p, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("got unexpected error: %v", err)
+		}
+		got = append(got, p)
+	}
+
+	if len(got) != n {
+		t.Fatalf("got %d records; want %d", len(got), n)
+	}
+	for i := 1; i < n; i++ {
+		if c := cmpA(got[i-1], got[i]); c > 0 || c == 0 && got[i-1].b > got[i].b {
+			t.Fatalf("got unstable order at %d: %v, %v", i, got[i-1], got[i])
+		}
+	}
+}
+
+func TestSort_LengthPrefixed(t *testing.T) {
+	records := make([][]byte, 1000)
+	var src bytes.Buffer
+	enc := external.LengthPrefixed{}.NewEncoder(&src)
+	for i := range records {
+		records[i] = make([]byte, rand.Intn(8))
+		for j := range records[i] {
+			records[i][j] = byte(rand.Intn(256))
+		}
+		if err := enc.Encode(records[i]); err != nil {
+			t.Fatalf("got unexpected error: %v", err)
+		}
+	}
+
+	var dst bytes.Buffer
+	opts := &external.Options{ChunkSize: 50, TempDir: t.TempDir()}
+	if err := external.SortFunc(&dst, &src, external.LengthPrefixed{}, bytes.Compare, opts); err != nil {
+		t.Fatalf("got unexpected error: %v", err)
+	}
+
+	slices.SortFunc(records, bytes.Compare)
+	dec := external.LengthPrefixed{}.NewDecoder(&dst)
+	for _, want := range records {
+		got, err := dec.Decode()
+		if err != nil {
+			t.Fatalf("got unexpected error: %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("got %q; want %q", got, want)
+		}
+	}
+}
+
+func TestSort_ChunkBytes(t *testing.T) {
+	// The temporary directory doesn't exist, so spilling a run fails.
+	dir := t.TempDir() + "/missing"
+	src := strings.Repeat("abcdefghi\n", 10)
+
+	var dst bytes.Buffer
+	opts := &external.Options{ChunkBytes: len(src), TempDir: dir}
+	if err := external.Sort(&dst, strings.NewReader(src), external.Lines{}, opts); err != nil {
+		t.Fatalf("got unexpected error: %v", err)
+	}
+	if got := dst.String(); got != src {
+		t.Errorf("got %q; want %q", got, src)
+	}
+
+	opts.ChunkBytes = len(src) - 1
+	if err := external.Sort(io.Discard, strings.NewReader(src), external.Lines{}, opts); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("got %v; want %v from spilling a run", err, os.ErrNotExist)
+	}
+}
+
+func TestSort_ChunkSize(t *testing.T) {
+	// The temporary directory doesn't exist, so spilling a run fails.
+	dir := t.TempDir() + "/missing"
+	src := "3\n1\n2\n"
+
+	var dst bytes.Buffer
+	opts := &external.Options{ChunkSize: 3, TempDir: dir}
+	if err := external.Sort(&dst, strings.NewReader(src), external.Lines{}, opts); err != nil {
+		t.Fatalf("got unexpected error: %v", err)
+	}
+	if got, want := dst.String(), "1\n2\n3\n"; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	opts.ChunkSize = 2
+	if err := external.Sort(io.Discard, strings.NewReader(src), external.Lines{}, opts); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("got %v; want %v from spilling a run", err, os.ErrNotExist)
+	}
+}
+
+// countingCodec is the Lines codec counting the encoded records.
+type countingCodec struct {
+	external.Lines
+	encoded *int
+}
+
+type countingEncoder struct {
+	external.Encoder[string]
+	encoded *int
+}
+
+func (c countingCodec) NewEncoder(w io.Writer) external.Encoder[string] {
+	return countingEncoder{c.Lines.NewEncoder(w), c.encoded}
+}
+
+func (e countingEncoder) Encode(v string) error {
+	*e.encoded++
+	return e.Encoder.Encode(v)
+}
+
+func TestSort_EncodeOnce(t *testing.T) {
+	n := 1000
+	tests := []struct {
+		opts external.Options
+		want int
+	}{
+		// The records are encoded when they're read into a chunk, and the chunk is written without encoding them again.
+		{external.Options{}, n},
+		{external.Options{ChunkSize: n}, n},
+		// The records are encoded again in each merge pass: the 10 runs are merged into dst,
+		// or into 3 runs and then into dst.
+		{external.Options{ChunkSize: 100}, 2 * n},
+		{external.Options{ChunkSize: 100, FanIn: 4}, 3 * n},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("chunk=%d,bytes=%d,fanin=%d", tt.opts.ChunkSize, tt.opts.ChunkBytes, tt.opts.FanIn), func(t *testing.T) {
+			tt.opts.TempDir = t.TempDir()
+
+			var src strings.Builder
+			for i := 0; i < n; i++ {
+				fmt.Fprintln(&src, rand.Intn(n))
+			}
+
+			encoded := 0
+			if err := external.Sort(io.Discard, strings.NewReader(src.String()), countingCodec{encoded: &encoded}, &tt.opts); err != nil {
+				t.Fatalf("got unexpected error: %v", err)
+			}
+			if encoded != tt.want {
+				t.Errorf("got %d encoded records; want %d", encoded, tt.want)
+			}
+		})
+	}
+}
+
+func TestSort_DecodeError(t *testing.T) {
+	src := bytes.NewReader([]byte{1, 'a', 1, 'b', 5, 'c'})
+	dir := t.TempDir()
+	opts := &external.Options{ChunkSize: 1, TempDir: dir}
+
+	err := external.SortFunc(io.Discard, src, external.LengthPrefixed{}, bytes.Compare, opts)
+
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("got %v; want %v", err, io.ErrUnexpectedEOF)
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Errorf("got %d temporary files left; want 0", len(files))
+	}
+}
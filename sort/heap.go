@@ -0,0 +1,221 @@
+package sort
+
+// HeapFunc implements heapsort using a custom comparison function.
+// It runs in O(n*log(n)) time in the worst case and doesn't allocate.
+// The sort is not guaranteed to be stable.
+func HeapFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	heapSort(s, cmp, nil)
+}
+
+func heapSort[E any](s []E, cmp func(a, b E) int, p *probe) {
+	n := len(s)
+
+	for i := (n - 1) / 2; i >= 0; i-- {
+		siftDown(s, i, n, cmp, p)
+	}
+	for i := n - 1; i > 0; i-- {
+		s[0], s[i] = s[i], s[0]
+		p.swap(0, i)
+		siftDown(s, 0, i, cmp, p)
+	}
+}
+
+// siftDown restores the max-heap property of s[:n] by sifting down the element at index i.
+func siftDown[S ~[]E, E any](s S, i, n int, cmp func(a, b E) int, p *probe) {
+	if p.traced() {
+		siftDownTraced(s, i, n, cmp, p)
+		return
+	}
+
+	for {
+		child := 2*i + 1
+		if child >= n {
+			return
+		}
+		if child+1 < n && cmp(s[child], s[child+1]) < 0 {
+			child++
+		}
+		if cmp(s[i], s[child]) >= 0 {
+			return
+		}
+		s[i], s[child] = s[child], s[i]
+		p.move(2)
+		i = child
+	}
+}
+
+// siftDownTraced is the same as siftDown, but reports every operation to the probe.
+func siftDownTraced[S ~[]E, E any](s S, i, n int, cmp func(a, b E) int, p *probe) {
+	for {
+		child := 2*i + 1
+		if child >= n {
+			return
+		}
+		if child+1 < n {
+			p.compare(child, child+1)
+			if cmp(s[child], s[child+1]) < 0 {
+				child++
+			}
+		}
+		p.compare(i, child)
+		if cmp(s[i], s[child]) >= 0 {
+			return
+		}
+		s[i], s[child] = s[child], s[i]
+		p.swap(i, child)
+		i = child
+	}
+}
+
+// Heap is a d-ary min-heap ordered by a custom comparison function.
+// The minimum element is at index 0.
+// The zero value isn't usable, since it has no comparison function; a Heap must be created by [NewHeap].
+type Heap[E any] struct {
+	s     []E
+	d     int
+	cmp   func(a, b E) int
+	moved func(e E, i int)
+}
+
+// NewHeap returns a d-ary heap of the elements of s ordered by the comparison function cmp.
+// The heap takes ownership of s and establishes the heap order in O(n) time.
+// If moved is not nil, it's called with an element and its index each time the element is placed at a new index,
+// which allows tracking the indexes of the elements for [Heap.Fix] and [Heap.Remove].
+// It panics if d < 2.
+func NewHeap[E any](s []E, d int, cmp func(a, b E) int, moved func(e E, i int)) *Heap[E] {
+	if d < 2 {
+		panic("sort: heap arity must be at least 2")
+	}
+
+	h := &Heap[E]{s: s, d: d, cmp: cmp, moved: moved}
+
+	n := len(s)
+	for i := (n - 2) / d; i >= 0; i-- {
+		h.down(i)
+	}
+	if moved != nil {
+		for i, v := range s {
+			moved(v, i)
+		}
+	}
+
+	return h
+}
+
+// Len returns the number of elements in the heap.
+func (h *Heap[E]) Len() int {
+	return len(h.s)
+}
+
+// Peek returns the minimum element of the heap without removing it.
+// It panics if the heap is empty.
+// The complexity is O(1).
+func (h *Heap[E]) Peek() E {
+	if len(h.s) == 0 {
+		panic("sort: Peek on empty heap")
+	}
+	return h.s[0]
+}
+
+// Push pushes the element v onto the heap.
+// The complexity is O(log(n)).
+func (h *Heap[E]) Push(v E) {
+	h.s = append(h.s, v)
+	h.up(len(h.s) - 1)
+}
+
+// Pop removes and returns the minimum element of the heap.
+// It panics if the heap is empty.
+// The complexity is O(d*log(n)).
+func (h *Heap[E]) Pop() E {
+	if len(h.s) == 0 {
+		panic("sort: Pop on empty heap")
+	}
+	return h.Remove(0)
+}
+
+// Remove removes and returns the element at index i of the heap.
+// It panics if i is out of range.
+// The complexity is O(d*log(n)).
+func (h *Heap[E]) Remove(i int) E {
+	if i < 0 || i >= len(h.s) {
+		panic("sort: heap index out of range")
+	}
+	n := len(h.s) - 1
+	v := h.s[i]
+
+	if i != n {
+		h.s[i] = h.s[n]
+	}
+	var zero E
+	h.s[n] = zero // avoid retaining a reference to the removed element
+	h.s = h.s[:n]
+
+	if i != n {
+		h.Fix(i)
+	}
+
+	return v
+}
+
+// Fix re-establishes the heap order after the element at index i has changed its value.
+// The complexity is O(d*log(n)).
+func (h *Heap[E]) Fix(i int) {
+	if !h.down(i) {
+		h.up(i)
+	}
+}
+
+// up moves the element at index i up the heap.
+func (h *Heap[E]) up(i int) {
+	v := h.s[i]
+
+	for i > 0 {
+		p := (i - 1) / h.d
+		if h.cmp(v, h.s[p]) >= 0 {
+			break
+		}
+		h.set(i, h.s[p])
+		i = p
+	}
+	h.set(i, v)
+}
+
+// down moves the element at index i down the heap and reports whether it was moved.
+func (h *Heap[E]) down(i int) bool {
+	i0 := i
+	v := h.s[i]
+	n := len(h.s)
+
+	for {
+		first := h.d*i + 1
+		if first >= n {
+			break
+		}
+
+		// Find the minimum child.
+		c := first
+		for j := first + 1; j < min(first+h.d, n); j++ {
+			if h.cmp(h.s[j], h.s[c]) < 0 {
+				c = j
+			}
+		}
+
+		if h.cmp(h.s[c], v) >= 0 {
+			break
+		}
+		h.set(i, h.s[c])
+		i = c
+	}
+	h.set(i, v)
+
+	return i > i0
+}
+
+// set places the element v at index i.
+func (h *Heap[E]) set(i int, v E) {
+	h.s[i] = v
+	if h.moved != nil {
+		h.moved(v, i)
+	}
+}
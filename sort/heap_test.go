@@ -0,0 +1,166 @@
+package sort_test
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+var heapFuncData = []int{74, 59, 238, -784, 9845, 959, 905, 0, 0, 42, 7586, -5467984, 7586}
+
+func TestHeapFunc_EmptyNil(t *testing.T) {
+	testSortFuncEmptyNil(t, sort.HeapFunc[[]int], cmp.Compare[int])
+}
+
+func TestHeapFunc_Data(t *testing.T) {
+	testSortFuncData(t, sort.HeapFunc[[]int], cmp.Compare[int], heapFuncData)
+}
+
+func TestHeapFunc_Reverse(t *testing.T) {
+	testSortFuncReverse(t, sort.HeapFunc[[]int], cmp.Compare[int], heapFuncData)
+}
+
+func TestHeapFunc_RandomInts(t *testing.T) {
+	testSortFuncRandomInts(t, sort.HeapFunc[[]int], cmp.Compare[int])
+}
+
+func TestHeapFunc_Patterns(t *testing.T) {
+	testSortFuncPatterns(t, sort.HeapFunc[[]int], cmp.Compare[int])
+}
+
+func TestHeap(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		t.Run(fmt.Sprintf("d=%d", d), func(t *testing.T) {
+			data := rand.Perm(1000)
+			h := sort.NewHeap(slices.Clone(data[:500]), d, cmp.Compare[int], nil)
+			for _, v := range data[500:] {
+				h.Push(v)
+			}
+
+			if h.Len() != len(data) {
+				t.Fatalf("Len() = %d; want %d", h.Len(), len(data))
+			}
+			for i := 0; i < len(data); i++ {
+				if v := h.Peek(); v != i {
+					t.Fatalf("Peek() = %d; want %d", v, i)
+				}
+				if v := h.Pop(); v != i {
+					t.Fatalf("Pop() = %d; want %d", v, i)
+				}
+			}
+			if h.Len() != 0 {
+				t.Fatalf("Len() = %d; want 0", h.Len())
+			}
+		})
+	}
+}
+
+type heapItem struct {
+	priority int
+	index    int
+}
+
+func TestHeap_FixRemove(t *testing.T) {
+	for _, d := range []int{2, 3, 4} {
+		t.Run(fmt.Sprintf("d=%d", d), func(t *testing.T) {
+			n := 1000
+			items := make([]*heapItem, n)
+			for i := range items {
+				items[i] = &heapItem{priority: rand.Intn(n)}
+			}
+
+			h := sort.NewHeap(slices.Clone(items), d,
+				func(a, b *heapItem) int { return cmp.Compare(a.priority, b.priority) },
+				func(e *heapItem, i int) { e.index = i },
+			)
+
+			// Change the priorities of some items and remove others.
+			var removed int
+			for i, it := range items {
+				switch i % 3 {
+				case 0:
+					it.priority = rand.Intn(n)
+					h.Fix(it.index)
+				case 1:
+					if v := h.Remove(it.index); v != it {
+						t.Fatalf("Remove(%d) = %v; want %v", it.index, v, it)
+					}
+					it.priority = -1
+					removed++
+				}
+			}
+
+			if h.Len() != n-removed {
+				t.Fatalf("Len() = %d; want %d", h.Len(), n-removed)
+			}
+			var got []int
+			for h.Len() > 0 {
+				got = append(got, h.Pop().priority)
+			}
+			if !slices.IsSorted(got) {
+				t.Errorf("got unsorted priorities: %v", got)
+			}
+			if slices.Contains(got, -1) {
+				t.Errorf("got removed item")
+			}
+		})
+	}
+}
+
+func TestNewHeap_Panic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("got no panic; want panic")
+		}
+	}()
+
+	sort.NewHeap([]int{}, 1, cmp.Compare[int], nil)
+}
+
+func TestHeap_Empty(t *testing.T) {
+	h := sort.NewHeap([]int{}, 2, cmp.Compare[int], nil)
+	tests := map[string]func(){
+		"Peek":   func() { h.Peek() },
+		"Pop":    func() { h.Pop() },
+		"Remove": func() { h.Remove(0) },
+	}
+
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("got no panic; want panic")
+				}
+			}()
+
+			f()
+		})
+	}
+
+	h.Push(1)
+	if got := h.Pop(); got != 1 || h.Len() != 0 {
+		t.Errorf("got %d, Len() = %d; want 1, 0", got, h.Len())
+	}
+}
+
+func BenchmarkHeapFunc1K(b *testing.B) {
+	benchmarkSortFunc1K(b, sort.HeapFunc[[]int], cmp.Compare[int])
+}
+
+func BenchmarkHeapFuncRandom1M(b *testing.B) {
+	benchmarkSortFuncRandom(b, 1<<20, sort.HeapFunc[[]int], cmp.Compare[int])
+}
+
+func FuzzHeapFunc(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s []byte) {
+		sort.HeapFunc(s, cmp.Compare)
+
+		if !slices.IsSortedFunc(s, cmp.Compare) {
+			t.Errorf("slice was not sorted")
+		}
+	})
+}
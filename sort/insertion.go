@@ -2,6 +2,15 @@ package sort
 
 // InsertionFunc implements an insertion sort using a custom comparison function.
 func InsertionFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	insertion(s, cmp, nil)
+}
+
+func insertion[E any](s []E, cmp func(a, b E) int, p *probe) {
+	if p.traced() {
+		insertionTraced(s, cmp, p)
+		return
+	}
+
 	n := len(s)
 	for i := 1; i < n; i++ {
 		v := s[i]
@@ -11,5 +20,26 @@ func InsertionFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
 			j--
 		}
 		s[j+1] = v
+		p.move(i - j)
+	}
+}
+
+// insertionTraced is the same as insertion, but reports every operation to the probe.
+func insertionTraced[E any](s []E, cmp func(a, b E) int, p *probe) {
+	n := len(s)
+	for i := 1; i < n; i++ {
+		v := s[i]
+		j := i - 1
+		for j >= 0 {
+			p.compare(j, j+1)
+			if cmp(s[j], v) <= 0 {
+				break
+			}
+			s[j+1] = s[j]
+			p.write(j + 1)
+			j--
+		}
+		s[j+1] = v
+		p.write(j + 1)
 	}
 }
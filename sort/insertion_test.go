@@ -36,6 +36,35 @@ func BenchmarkInsertionFunc1K(b *testing.B) {
 	benchmarkSortFunc1K(b, sort.InsertionFunc[[]int], cmp.Compare[int])
 }
 
+// plainInsertionFunc is an insertion sort without the instrumentation of [sort.InsertionFunc].
+func plainInsertionFunc(s []int, cmp func(a, b int) int) {
+	for i := 1; i < len(s); i++ {
+		v := s[i]
+		j := i - 1
+		for j >= 0 && cmp(s[j], v) > 0 {
+			s[j+1] = s[j]
+			j--
+		}
+		s[j+1] = v
+	}
+}
+
+// BenchmarkInsertionFunc1K_Instrumentation compares InsertionFunc with a plain insertion sort, which it should match,
+// and with tracing the sort.
+func BenchmarkInsertionFunc1K_Instrumentation(b *testing.B) {
+	b.Run("Plain", func(b *testing.B) {
+		benchmarkSortFunc1K(b, plainInsertionFunc, cmp.Compare[int])
+	})
+	b.Run("InsertionFunc", func(b *testing.B) {
+		benchmarkSortFunc1K(b, sort.InsertionFunc[[]int], cmp.Compare[int])
+	})
+	b.Run("Trace", func(b *testing.B) {
+		benchmarkSortFunc1K(b, func(s []int, cmp func(a, b int) int) {
+			sort.Trace(sort.InsertionSort, s, cmp, func(sort.Event[int]) {})
+		}, cmp.Compare[int])
+	})
+}
+
 func FuzzInsertionSortFunc(f *testing.F) {
 	f.Fuzz(func(t *testing.T, s []byte) {
 		sort.InsertionFunc(s, cmp.Compare)
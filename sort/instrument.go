@@ -0,0 +1,234 @@
+package sort
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Algorithm identifies a comparison sorting algorithm of the package that can be measured using [Measure].
+type Algorithm int
+
+const (
+	InsertionSort     Algorithm = iota // InsertionFunc
+	SelectionSort                      // SelectionFunc
+	MergeSort                          // MergeFunc
+	MergeBottomUpSort                  // MergeBottomUpFunc
+	InPlaceStableSort                  // InPlaceStableFunc
+	ParallelMergeSort                  // ParallelMergeFunc with the default parameters
+	PdqSort                            // PdqFunc
+	TimSort                            // TimFunc
+	HeapSort                           // HeapFunc
+	ShellSort                          // ShellFunc
+)
+
+// Algorithms lists all the comparison sorting algorithms of the package.
+var Algorithms = []Algorithm{
+	InsertionSort,
+	SelectionSort,
+	MergeSort,
+	MergeBottomUpSort,
+	InPlaceStableSort,
+	ParallelMergeSort,
+	PdqSort,
+	TimSort,
+	HeapSort,
+	ShellSort,
+}
+
+var algorithmNames = [...]string{
+	InsertionSort:     "Insertion",
+	SelectionSort:     "Selection",
+	MergeSort:         "Merge",
+	MergeBottomUpSort: "MergeBottomUp",
+	InPlaceStableSort: "InPlaceStable",
+	ParallelMergeSort: "ParallelMerge",
+	PdqSort:           "Pdq",
+	TimSort:           "Tim",
+	HeapSort:          "Heap",
+	ShellSort:         "Shell",
+}
+
+// String returns the name of the algorithm.
+func (a Algorithm) String() string {
+	if a < 0 || int(a) >= len(algorithmNames) {
+		return fmt.Sprintf("Algorithm(%d)", int(a))
+	}
+	return algorithmNames[a]
+}
+
+// Stats holds the number of operations performed by a sorting algorithm.
+type Stats struct {
+	// Comparisons is the number of calls to the comparison function.
+	Comparisons int64
+	// Moves is the number of writes of elements to the slice or to auxiliary buffers.
+	// A swap counts as two moves.
+	Moves int64
+	// Allocs is the number of elements of the allocated auxiliary buffers.
+	Allocs int64
+	// MaxDepth is the maximum depth of recursive calls, or 0 if the algorithm is not recursive.
+	MaxDepth int64
+}
+
+// Measure sorts the slice using the algorithm and a custom comparison function,
+// and returns the number of operations the algorithm performed.
+// All algorithms report Comparisons and Moves.
+// Allocs is reported by the algorithms allocating auxiliary buffers: [MergeSort], [MergeBottomUpSort], [ParallelMergeSort] and [TimSort].
+// MaxDepth is reported by the recursive algorithms: [MergeSort], [InPlaceStableSort], [ParallelMergeSort] and [PdqSort].
+// It panics if the algorithm is unknown.
+//
+// The comparisons of the other sorts taking a comparison function, such as [SmallFunc], [NetworkFunc],
+// [NthElementFunc] and [PartialSortFunc], are counted by wrapping it using [CountComparisons].
+// [RadixFunc], [CountingSortFunc], [BucketSortFunc] and the multikey sorts don't compare elements.
+func Measure[S ~[]E, E any](alg Algorithm, s S, cmp func(a, b E) int) Stats {
+	var st Stats
+	p := &probe{stats: &st}
+
+	sortProbe(alg, s, CountComparisons(cmp, &st), p)
+
+	return st
+}
+
+// CountComparisons returns a comparison function that calls cmp and increments st.Comparisons for every call,
+// so it counts the comparisons of any sort taking a comparison function.
+// The increments are atomic, so the returned function is safe for concurrent use if cmp is.
+func CountComparisons[E any](cmp func(a, b E) int, st *Stats) func(a, b E) int {
+	return func(a, b E) int {
+		atomic.AddInt64(&st.Comparisons, 1)
+		return cmp(a, b)
+	}
+}
+
+// sortProbe sorts the slice using the algorithm, reporting the operations to the probe.
+func sortProbe[E any](alg Algorithm, s []E, cmp func(a, b E) int, p *probe) {
+	switch alg {
+	case InsertionSort:
+		insertion(s, cmp, p)
+	case SelectionSort:
+		selection(s, cmp, p)
+	case MergeSort:
+		p.alloc(len(s))
+		mergeSort(s, cmp, make([]E, len(s)), p)
+	case MergeBottomUpSort:
+		mergeBottomUp(s, cmp, p)
+	case InPlaceStableSort:
+		inPlaceStable(s, cmp, p)
+	case ParallelMergeSort:
+		parallelMergeSortFunc(s, cmp, 0, 0, p)
+	case PdqSort:
+		pdqFunc(s, cmp, p)
+	case TimSort:
+		timSortFunc(s, cmp, p)
+	case HeapSort:
+		heapSort(s, cmp, p)
+	case ShellSort:
+		shell(s, ciuraGaps, cmp, p)
+	default:
+		panic(fmt.Sprintf("sort: unknown algorithm %v", alg))
+	}
+}
+
+// probe observes the operations performed by a sorting algorithm.
+// All methods of a nil probe are no-ops, so the algorithms pass a nil probe when not being measured.
+type probe struct {
+	stats *Stats
+	depth int64 // current recursion depth
+
+	trace func(op Op, i, j int) // if not nil, called for every operation on the slice
+	off   int                   // offset of the slice passed to the algorithm in the traced slice
+}
+
+// traced reports whether the operations are reported to a trace function.
+// The algorithms check it once and run a separate traced loop, so their untraced loops don't check the probe for every operation.
+func (p *probe) traced() bool {
+	return p != nil && p.trace != nil
+}
+
+// move records n element moves.
+func (p *probe) move(n int) {
+	if p != nil {
+		atomic.AddInt64(&p.stats.Moves, int64(n))
+	}
+}
+
+// compare records a comparison of the elements at indexes i and j.
+func (p *probe) compare(i, j int) {
+	if p != nil && p.trace != nil {
+		p.trace(OpCompare, p.off+i, p.off+j)
+	}
+}
+
+// swap records a swap of the elements at indexes i and j.
+func (p *probe) swap(i, j int) {
+	if p != nil {
+		p.move(2)
+		if p.trace != nil {
+			p.trace(OpSwap, p.off+i, p.off+j)
+		}
+	}
+}
+
+// write records a write of the element at index i.
+func (p *probe) write(i int) {
+	p.writes(i, i+1)
+}
+
+// writes records writes of the elements at indexes [lo, hi).
+func (p *probe) writes(lo, hi int) {
+	if p != nil {
+		p.move(hi - lo)
+		if p.trace != nil {
+			for i := lo; i < hi; i++ {
+				p.trace(OpWrite, p.off+i, -1)
+			}
+		}
+	}
+}
+
+// alloc records an allocation of an auxiliary buffer of n elements.
+func (p *probe) alloc(n int) {
+	if p != nil {
+		atomic.AddInt64(&p.stats.Allocs, int64(n))
+	}
+}
+
+// enter records entering a recursive call.
+func (p *probe) enter() {
+	if p == nil {
+		return
+	}
+
+	p.depth++
+	for {
+		d := atomic.LoadInt64(&p.stats.MaxDepth)
+		if p.depth <= d || atomic.CompareAndSwapInt64(&p.stats.MaxDepth, d, p.depth) {
+			return
+		}
+	}
+}
+
+// leave records leaving a recursive call.
+func (p *probe) leave() {
+	if p != nil {
+		p.depth--
+	}
+}
+
+// fork returns a probe for a goroutine started at the current recursion depth.
+func (p *probe) fork() *probe {
+	if p == nil {
+		return nil
+	}
+	q := *p
+	return &q
+}
+
+// at returns a probe for the subslice starting at index off of the current slice.
+// Untraced probes don't depend on the offset, so they are returned as is.
+func (p *probe) at(off int) *probe {
+	if p == nil || p.trace == nil || off == 0 {
+		return p
+	}
+	q := *p
+	q.off += off
+	return &q
+}
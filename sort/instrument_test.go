@@ -0,0 +1,216 @@
+package sort_test
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+func TestMeasure(t *testing.T) {
+	n := 1000
+	for _, alg := range sort.Algorithms {
+		for name, p := range sortPatterns(n) {
+			t.Run(alg.String()+"/"+name, func(t *testing.T) {
+				data := make([]int, n)
+				for i := range data {
+					data[i] = p(i)
+				}
+
+				st := sort.Measure(alg, data, cmp.Compare[int])
+
+				if !slices.IsSortedFunc(data, cmp.Compare[int]) {
+					t.Fatalf("%v didn't sort %d ints of pattern %q", alg, n, name)
+				}
+				if st.Comparisons < int64(n-1) {
+					t.Errorf("Comparisons = %d; want at least %d", st.Comparisons, n-1)
+				}
+				if st.Moves < 0 || st.Allocs < 0 || st.MaxDepth < 0 {
+					t.Errorf("negative stats: %+v", st)
+				}
+			})
+		}
+	}
+}
+
+func TestMeasure_Insertion(t *testing.T) {
+	n := 100
+
+	data := make([]int, n)
+	for i := range data {
+		data[i] = n - i
+	}
+	st := sort.Measure(sort.InsertionSort, data, cmp.Compare[int])
+
+	want := sort.Stats{
+		Comparisons: int64(n * (n - 1) / 2),
+		Moves:       int64(n*(n-1)/2 + n - 1),
+	}
+	if st != want {
+		t.Errorf("Measure(InsertionSort) = %+v; want %+v", st, want)
+	}
+}
+
+func TestMeasure_Allocs(t *testing.T) {
+	n := 1000
+	tests := []struct {
+		alg    sort.Algorithm
+		allocs int64
+	}{
+		{sort.InsertionSort, 0},
+		{sort.SelectionSort, 0},
+		{sort.MergeSort, int64(n)},
+		{sort.MergeBottomUpSort, int64(n)},
+		{sort.InPlaceStableSort, 0},
+		{sort.ParallelMergeSort, int64(n)},
+		{sort.PdqSort, 0},
+		{sort.HeapSort, 0},
+		{sort.ShellSort, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.alg.String(), func(t *testing.T) {
+			st := sort.Measure(tt.alg, randomInts(n), cmp.Compare[int])
+			if st.Allocs != tt.allocs {
+				t.Errorf("Allocs = %d; want %d", st.Allocs, tt.allocs)
+			}
+		})
+	}
+
+	// The scratch space of Timsort grows with the merged runs.
+	if st := sort.Measure(sort.TimSort, randomInts(n), cmp.Compare[int]); st.Allocs == 0 {
+		t.Errorf("Tim: Allocs = 0; want > 0")
+	}
+}
+
+func TestMeasure_MaxDepth(t *testing.T) {
+	n := 1000
+	for _, alg := range sort.Algorithms {
+		t.Run(alg.String(), func(t *testing.T) {
+			st := sort.Measure(alg, randomInts(n), cmp.Compare[int])
+
+			switch alg {
+			case sort.InsertionSort, sort.SelectionSort, sort.MergeBottomUpSort, sort.TimSort, sort.HeapSort, sort.ShellSort:
+				if st.MaxDepth != 0 {
+					t.Errorf("MaxDepth = %d; want 0", st.MaxDepth)
+				}
+			case sort.MergeSort, sort.ParallelMergeSort:
+				// The recursion bottoms out at subslices of length 1.
+				if want := int64(11); st.MaxDepth != want {
+					t.Errorf("MaxDepth = %d; want %d", st.MaxDepth, want)
+				}
+			default:
+				if st.MaxDepth == 0 {
+					t.Errorf("MaxDepth = 0; want > 0")
+				}
+			}
+		})
+	}
+}
+
+func TestCountComparisons(t *testing.T) {
+	n := 100
+	tests := map[string]struct {
+		sort func(s []int, cmp func(a, b int) int)
+		min  int64 // the minimum number of comparisons
+		max  int64 // the maximum number of comparisons
+	}{
+		"SmallFunc": {
+			sort: func(s []int, cmp func(a, b int) int) { sort.SmallFunc(s[:sort.MaxSmall], cmp) },
+			min:  int64(len(sort.SmallNetwork(sort.MaxSmall))),
+			max:  int64(len(sort.SmallNetwork(sort.MaxSmall))),
+		},
+		"NthElementFunc": {
+			sort: func(s []int, cmp func(a, b int) int) { sort.NthElementFunc(s, n/2, cmp) },
+			min:  int64(n - 1),
+			max:  int64(n * n),
+		},
+		"PartialSortFunc": {
+			sort: func(s []int, cmp func(a, b int) int) { sort.PartialSortFunc(s, n/10, cmp) },
+			min:  int64(n - 1),
+			max:  int64(n * n),
+		},
+		"InsertionFunc": {
+			sort: sort.InsertionFunc[[]int],
+			min:  int64(n * (n - 1) / 2),
+			max:  int64(n * (n - 1) / 2),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := make([]int, n)
+			for i := range data {
+				data[i] = n - i
+			}
+
+			var st sort.Stats
+			tt.sort(data, sort.CountComparisons(cmp.Compare[int], &st))
+			if st.Comparisons < tt.min || st.Comparisons > tt.max {
+				t.Errorf("Comparisons = %d; want in [%d, %d]", st.Comparisons, tt.min, tt.max)
+			}
+			if st.Moves != 0 || st.Allocs != 0 || st.MaxDepth != 0 {
+				t.Errorf("CountComparisons changed the other stats: %+v", st)
+			}
+		})
+	}
+}
+
+func TestMeasure_Unknown(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Measure didn't panic on an unknown algorithm")
+		}
+	}()
+	sort.Measure(sort.Algorithm(-1), []int{2, 1}, cmp.Compare[int])
+}
+
+func TestAlgorithm_String(t *testing.T) {
+	if s := sort.PdqSort.String(); s != "Pdq" {
+		t.Errorf("PdqSort.String() = %q; want %q", s, "Pdq")
+	}
+	if s := sort.Algorithm(-1).String(); s != "Algorithm(-1)" {
+		t.Errorf("Algorithm(-1).String() = %q; want %q", s, "Algorithm(-1)")
+	}
+}
+
+func BenchmarkMeasure(b *testing.B) {
+	n := 1 << 12
+	for _, alg := range sort.Algorithms {
+		for name, p := range sortPatterns(n) {
+			b.Run(alg.String()+"/"+name, func(b *testing.B) {
+				data := make([]int, n)
+				for i := range data {
+					data[i] = p(i)
+				}
+
+				var total sort.Stats
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					s := slices.Clone(data)
+					b.StartTimer()
+
+					st := sort.Measure(alg, s, cmp.Compare[int])
+					total.Comparisons += st.Comparisons
+					total.Moves += st.Moves
+					total.Allocs += st.Allocs
+					total.MaxDepth = max(total.MaxDepth, st.MaxDepth)
+				}
+
+				b.ReportMetric(float64(total.Comparisons)/float64(b.N), "cmps/op")
+				b.ReportMetric(float64(total.Moves)/float64(b.N), "moves/op")
+				b.ReportMetric(float64(total.Allocs)/float64(b.N), "elems-alloc/op")
+				b.ReportMetric(float64(total.MaxDepth), "max-depth")
+			})
+		}
+	}
+}
+
+func randomInts(n int) []int {
+	data := make([]int, n)
+	for i := range data {
+		data[i] = rand.Intn(n)
+	}
+	return data
+}
@@ -1,49 +1,96 @@
 package sort
 
 // MergeFunc implements top-down merge sort using custom comparison function.
+// It runs in O(n*log(n)) time and requires O(n) auxiliary space.
+// The sort is stable.
 func MergeFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
-	aux := make([]E, len(s))
-
-	var sort func(s S, cmp func(a, b E) int, aux S)
-	sort = func(s S, cmp func(a, b E) int, aux S) {
-		n := len(s)
-		if n <= 1 {
-			return
-		}
-
-		m := n / 2
-
-		sort(s[:m], cmp, aux)
-		sort(s[m:], cmp, aux)
-		merge(s[:m], s[m:], cmp, aux)
-
-		copy(s, aux)
-	}
-	sort(s, cmp, aux)
+	mergeSort(s, cmp, make([]E, len(s)), nil)
 }
 
 // MergeBottomUpFunc implements bottom-up merge sort using custom comparison function.
+// It runs in O(n*log(n)) time and requires O(n) auxiliary space.
+// The sort is stable.
 func MergeBottomUpFunc[S ~[]E, E any](s S, cmp func(x, y E) int) {
+	mergeBottomUp(s, cmp, nil)
+}
+
+func mergeBottomUp[S ~[]E, E any](s S, cmp func(x, y E) int, p *probe) {
 	n := len(s)
 
 	aux := make([]E, len(s))
+	p.alloc(n)
 
 	for sz := 1; sz < n; sz *= 2 {
 		for l := 0; l < n-sz; l += 2 * sz {
 			m := l + sz
 			r := min(l+2*sz, n)
 
-			merge(s[l:m], s[m:r], cmp, aux)
+			merge(s[l:m], s[m:r], cmp, aux, p.at(l))
 
 			copy(s[l:r], aux)
+			p.writes(l, r)
+		}
+	}
+}
+
+// InPlaceStableFunc implements in-place bottom-up merge sort using custom comparison function.
+// Blocks of the slice are sorted using insertion sort and then merged in place using the SymMerge algorithm,
+// which rotates parts of the merged subslices instead of copying them to an auxiliary buffer.
+// It performs O(n*log(n)) comparisons and O(n*log(n)*log(n)) swaps and requires O(log(n)) auxiliary space for the recursion stack.
+// It trades the speed of [MergeFunc] for not allocating, which matters for huge slices of large elements.
+// The sort is stable.
+//
+// See P. S. Kim and A. Kutzner, "Stable Minimum Storage Merging by Symmetric Comparisons", 2004.
+func InPlaceStableFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	inPlaceStable(s, cmp, nil)
+}
+
+func inPlaceStable[S ~[]E, E any](s S, cmp func(a, b E) int, p *probe) {
+	const blockSize = 20
+
+	n := len(s)
+
+	for l := 0; l < n; l += blockSize {
+		insertion(s[l:min(l+blockSize, n)], cmp, p)
+	}
+
+	for sz := blockSize; sz < n; sz *= 2 {
+		for l := 0; l < n-sz; l += 2 * sz {
+			symMerge(s[l:min(l+2*sz, n)], sz, cmp, p)
 		}
 	}
 }
 
+// mergeSort implements top-down merge sort using aux as a scratch space.
+func mergeSort[S ~[]E, E any](s S, cmp func(a, b E) int, aux S, p *probe) {
+	p.enter()
+	defer p.leave()
+
+	n := len(s)
+	if n <= 1 {
+		return
+	}
+
+	m := n / 2
+
+	mergeSort(s[:m], cmp, aux, p)
+	mergeSort(s[m:], cmp, aux, p.at(m))
+	merge(s[:m], s[m:], cmp, aux, p)
+
+	copy(s, aux)
+	p.writes(0, n)
+}
+
 // merge merges two sorted slices s1 and s2 into one sorted slice aux.
-func merge[S ~[]E, E any](s1, s2 S, cmp func(x, y E) int, aux S) {
+func merge[S ~[]E, E any](s1, s2 S, cmp func(x, y E) int, aux S, p *probe) {
+	if p.traced() {
+		mergeTraced(s1, s2, cmp, aux, p)
+		return
+	}
+
 	n1, n2 := len(s1), len(s2)
 	n := n1 + n2
+	p.move(n)
 
 	for i, j, k := 0, 0, 0; k < n; k++ {
 		switch {
@@ -62,3 +109,104 @@ func merge[S ~[]E, E any](s1, s2 S, cmp func(x, y E) int, aux S) {
 		}
 	}
 }
+
+// mergeTraced is the same as merge, but reports every comparison to the probe as if s2 immediately followed s1.
+func mergeTraced[S ~[]E, E any](s1, s2 S, cmp func(x, y E) int, aux S, p *probe) {
+	n1, n2 := len(s1), len(s2)
+	n := n1 + n2
+	p.move(n)
+
+	for i, j, k := 0, 0, 0; k < n; k++ {
+		switch {
+		case i >= n1:
+			aux[k] = s2[j]
+			j++
+		case j >= n2:
+			aux[k] = s1[i]
+			i++
+		default:
+			p.compare(i, n1+j)
+			if cmp(s1[i], s2[j]) <= 0 { // ensures stability
+				aux[k] = s1[i]
+				i++
+			} else {
+				aux[k] = s2[j]
+				j++
+			}
+		}
+	}
+}
+
+// symMerge merges two sorted subslices s[:m] and s[m:] in place using the SymMerge algorithm.
+// Both subslices must be non-empty.
+func symMerge[S ~[]E, E any](s S, m int, cmp func(x, y E) int, p *probe) {
+	p.enter()
+	defer p.leave()
+
+	n := len(s)
+
+	// Insert s[0] into s[1:] after the elements less than it.
+	if m == 1 {
+		i, j := 1, n
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if cmp(s[h], s[0]) < 0 {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		rotate(s[:i], 1, p)
+		return
+	}
+
+	// Insert s[m] into s[:m] after the elements less than or equal to it.
+	if n-m == 1 {
+		i, j := 0, m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if cmp(s[m], s[h]) >= 0 {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		rotate(s[i:], m-i, p)
+		return
+	}
+
+	// Find the largest start such that s[start:m] and s[m:end] can be swapped by a rotation,
+	// where the split points are symmetric around the middle of the slice.
+	mid := n / 2
+	k := mid + m
+	start, r := 0, m
+	if m > mid {
+		start, r = k-n, mid
+	}
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if cmp(s[k-1-c], s[c]) >= 0 {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+	end := k - start
+
+	if start < m && m < end {
+		rotate(s[start:end], m-start, p)
+	}
+	if 0 < start && start < mid {
+		symMerge(s[:mid], start, cmp, p)
+	}
+	if mid < end && end < n {
+		symMerge(s[mid:], end-mid, cmp, p)
+	}
+}
+
+// rotate rotates the slice s to the left by k positions.
+func rotate[S ~[]E, E any](s S, k int, p *probe) {
+	reverse(s[:k], p)
+	reverse(s[k:], p)
+	reverse(s, p)
+}
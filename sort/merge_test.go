@@ -17,6 +17,9 @@ func TestMergeFunc_EmptyNil(t *testing.T) {
 	t.Run("BottomUp", func(t *testing.T) {
 		testSortFuncEmptyNil(t, sort.MergeBottomUpFunc[[]int], cmp.Compare[int])
 	})
+	t.Run("InPlace", func(t *testing.T) {
+		testSortFuncEmptyNil(t, sort.InPlaceStableFunc[[]int], cmp.Compare[int])
+	})
 }
 
 func TestMergeFunc_Data(t *testing.T) {
@@ -26,6 +29,9 @@ func TestMergeFunc_Data(t *testing.T) {
 	t.Run("BottomUp", func(t *testing.T) {
 		testSortFuncData(t, sort.MergeBottomUpFunc[[]int], cmp.Compare[int], mergeFuncData)
 	})
+	t.Run("InPlace", func(t *testing.T) {
+		testSortFuncData(t, sort.InPlaceStableFunc[[]int], cmp.Compare[int], mergeFuncData)
+	})
 }
 
 func TestMergeFunc_Reverse(t *testing.T) {
@@ -35,6 +41,9 @@ func TestMergeFunc_Reverse(t *testing.T) {
 	t.Run("BottomUp", func(t *testing.T) {
 		testSortFuncReverse(t, sort.MergeBottomUpFunc[[]int], cmp.Compare[int], mergeFuncData)
 	})
+	t.Run("InPlace", func(t *testing.T) {
+		testSortFuncReverse(t, sort.InPlaceStableFunc[[]int], cmp.Compare[int], mergeFuncData)
+	})
 }
 
 func TestMergeFunc_RandomInts(t *testing.T) {
@@ -44,6 +53,21 @@ func TestMergeFunc_RandomInts(t *testing.T) {
 	t.Run("BottomUp", func(t *testing.T) {
 		testSortFuncRandomInts(t, sort.MergeBottomUpFunc[[]int], cmp.Compare[int])
 	})
+	t.Run("InPlace", func(t *testing.T) {
+		testSortFuncRandomInts(t, sort.InPlaceStableFunc[[]int], cmp.Compare[int])
+	})
+}
+
+func TestMergeFunc_Patterns(t *testing.T) {
+	t.Run("TopDown", func(t *testing.T) {
+		testSortFuncPatterns(t, sort.MergeFunc[[]int], cmp.Compare[int])
+	})
+	t.Run("BottomUp", func(t *testing.T) {
+		testSortFuncPatterns(t, sort.MergeBottomUpFunc[[]int], cmp.Compare[int])
+	})
+	t.Run("InPlace", func(t *testing.T) {
+		testSortFuncPatterns(t, sort.InPlaceStableFunc[[]int], cmp.Compare[int])
+	})
 }
 
 func TestMergeFunc_Stability(t *testing.T) {
@@ -57,6 +81,9 @@ func TestMergeFunc_Stability(t *testing.T) {
 	t.Run("BottomUp", func(t *testing.T) {
 		testSortFuncStability(t, sort.MergeBottomUpFunc[intPairs], n, m)
 	})
+	t.Run("InPlace", func(t *testing.T) {
+		testSortFuncStability(t, sort.InPlaceStableFunc[intPairs], n, m)
+	})
 }
 
 func BenchmarkMergeFunc1K(b *testing.B) {
@@ -66,6 +93,33 @@ func BenchmarkMergeFunc1K(b *testing.B) {
 	b.Run("BottomUp", func(b *testing.B) {
 		benchmarkSortFunc1K(b, sort.MergeBottomUpFunc[[]int], cmp.Compare[int])
 	})
+	b.Run("InPlace", func(b *testing.B) {
+		benchmarkSortFunc1K(b, sort.InPlaceStableFunc[[]int], cmp.Compare[int])
+	})
+}
+
+func BenchmarkMergeFuncRandom1M(b *testing.B) {
+	b.Run("TopDown", func(b *testing.B) {
+		benchmarkSortFuncRandom(b, 1<<20, sort.MergeFunc[[]int], cmp.Compare[int])
+	})
+	b.Run("BottomUp", func(b *testing.B) {
+		benchmarkSortFuncRandom(b, 1<<20, sort.MergeBottomUpFunc[[]int], cmp.Compare[int])
+	})
+	b.Run("InPlace", func(b *testing.B) {
+		benchmarkSortFuncRandom(b, 1<<20, sort.InPlaceStableFunc[[]int], cmp.Compare[int])
+	})
+}
+
+func BenchmarkMergeFuncAppended1M(b *testing.B) {
+	b.Run("TopDown", func(b *testing.B) {
+		benchmarkSortFuncAppended(b, 1<<20, sort.MergeFunc[[]int], cmp.Compare[int])
+	})
+	b.Run("BottomUp", func(b *testing.B) {
+		benchmarkSortFuncAppended(b, 1<<20, sort.MergeBottomUpFunc[[]int], cmp.Compare[int])
+	})
+	b.Run("InPlace", func(b *testing.B) {
+		benchmarkSortFuncAppended(b, 1<<20, sort.InPlaceStableFunc[[]int], cmp.Compare[int])
+	})
 }
 
 func FuzzMergeSortFunc(f *testing.F) {
@@ -87,3 +141,13 @@ func FuzzMergeBottomUpSortFunc(f *testing.F) {
 		}
 	})
 }
+
+func FuzzInPlaceStableFunc(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s []byte) {
+		sort.InPlaceStableFunc(s, cmp.Compare)
+
+		if !slices.IsSortedFunc(s, cmp.Compare) {
+			t.Errorf("slice was not sorted")
+		}
+	})
+}
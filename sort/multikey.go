@@ -0,0 +1,171 @@
+package sort
+
+// multikeyInsertionCutoff is the length of a subslice below which insertion sort is used.
+const multikeyInsertionCutoff = 16
+
+// bytestring is a string or a byte slice.
+type bytestring interface {
+	~string | ~[]byte
+}
+
+// MultikeyStrings implements multikey quicksort (three-way radix quicksort) of strings.
+// The strings are partitioned three-way by a single byte at a time, so the common prefixes are never compared again.
+// It runs in O(w*n) expected time, where w is the average length of the distinguishing prefixes of the strings,
+// and doesn't allocate.
+// The sort is not guaranteed to be stable.
+//
+// See J. L. Bentley and R. Sedgewick, "Fast Algorithms for Sorting and Searching Strings", 1997.
+func MultikeyStrings[S ~[]E, E ~string](s S) {
+	multikey(s, 0, nil)
+}
+
+// MultikeyBytes implements multikey quicksort (three-way radix quicksort) of byte slices.
+// It's the same as [MultikeyStrings], but for byte slices.
+func MultikeyBytes[S ~[]E, E ~[]byte](s S) {
+	multikey(s, 0, nil)
+}
+
+// MultikeyStringsLCP is the same as [MultikeyStrings], but also returns the LCP array of the sorted slice:
+// the element with index i > 0 is the length of the longest common prefix of s[i-1] and s[i], and the first element is 0.
+// The LCP array is computed during the sort from the lengths of the prefixes already known to be common.
+func MultikeyStringsLCP[S ~[]E, E ~string](s S) []int {
+	lcp := make([]int, len(s))
+	multikey(s, 0, lcp)
+	return lcp
+}
+
+// MultikeyBytesLCP is the same as [MultikeyBytes], but also returns the LCP array of the sorted slice,
+// as described in [MultikeyStringsLCP].
+func MultikeyBytesLCP[S ~[]E, E ~[]byte](s S) []int {
+	lcp := make([]int, len(s))
+	multikey(s, 0, lcp)
+	return lcp
+}
+
+// multikey sorts s whose elements share a common prefix of length d.
+// If lcp is not nil, it fills lcp[1:] with the lengths of the longest common prefixes of the adjacent sorted elements;
+// lcp[0] is set by the caller.
+func multikey[E bytestring](s []E, d int, lcp []int) {
+	for len(s) > multikeyInsertionCutoff {
+		n := len(s)
+		pivot := medianByte(byteAt(s[0], d), byteAt(s[n/2], d), byteAt(s[n-1], d))
+
+		// Partition the elements by the byte at index d, the same way as partition.ThreeWay:
+		// byte < pivot: [0, lt-1], byte == pivot: [lt, gt], byte > pivot: [gt+1, n-1].
+		lt, gt := 0, n-1
+		for i := 0; i <= gt; {
+			switch b := byteAt(s[i], d); {
+			case b < pivot:
+				s[lt], s[i] = s[i], s[lt]
+				lt++
+				i++
+			case b == pivot:
+				i++
+			default:
+				s[i], s[gt] = s[gt], s[i]
+				gt--
+			}
+		}
+
+		// Adjacent elements from different partitions differ exactly at index d.
+		if lcp != nil {
+			if lt > 0 {
+				lcp[lt] = d
+			}
+			if gt+1 < n {
+				lcp[gt+1] = d
+			}
+		}
+
+		multikey(s[:lt], d, subLCP(lcp, 0, lt))
+		multikey(s[gt+1:], d, subLCP(lcp, gt+1, n))
+
+		// The elements ending at index d are all equal.
+		if pivot < 0 {
+			if lcp != nil {
+				for i := lt + 1; i <= gt; i++ {
+					lcp[i] = d
+				}
+			}
+			return
+		}
+
+		s, lcp = s[lt:gt+1], subLCP(lcp, lt, gt+1)
+		d++
+	}
+
+	multikeyInsertion(s, d, lcp)
+}
+
+// multikeyInsertion sorts s whose elements share a common prefix of length d using insertion sort.
+func multikeyInsertion[E bytestring](s []E, d int, lcp []int) {
+	n := len(s)
+	for i := 1; i < n; i++ {
+		v := s[i]
+		j := i - 1
+		for j >= 0 {
+			if c, _ := compareFrom(s[j], v, d); c <= 0 {
+				break
+			}
+			s[j+1] = s[j]
+			j--
+		}
+		s[j+1] = v
+	}
+
+	if lcp != nil {
+		for i := 1; i < n; i++ {
+			_, lcp[i] = compareFrom(s[i-1], s[i], d)
+		}
+	}
+}
+
+// compareFrom compares a and b that share a common prefix of length d,
+// and returns the result of the comparison and the length of their longest common prefix.
+func compareFrom[E bytestring](a, b E, d int) (c, l int) {
+	n := min(len(a), len(b))
+	for l = d; l < n; l++ {
+		if a[l] != b[l] {
+			if a[l] < b[l] {
+				return -1, l
+			}
+			return +1, l
+		}
+	}
+
+	switch {
+	case len(a) < len(b):
+		return -1, l
+	case len(a) > len(b):
+		return +1, l
+	default:
+		return 0, l
+	}
+}
+
+// byteAt returns the byte of v at index d, or -1 if v ends before index d.
+func byteAt[E bytestring](v E, d int) int {
+	if d >= len(v) {
+		return -1
+	}
+	return int(v[d])
+}
+
+// medianByte returns the median of a, b and c.
+func medianByte(a, b, c int) int {
+	if a > b {
+		a, b = b, a
+	}
+	if b > c {
+		b = c
+	}
+	return max(a, b)
+}
+
+// subLCP returns lcp[lo:hi], or nil if lcp is nil.
+func subLCP(lcp []int, lo, hi int) []int {
+	if lcp == nil {
+		return nil
+	}
+	return lcp[lo:hi]
+}
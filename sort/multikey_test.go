@@ -0,0 +1,161 @@
+package sort_test
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+// multikeyData returns n random strings with long shared prefixes, like URLs or file paths.
+func multikeyData(n int) []string {
+	prefixes := []string{"", "a", "ab", "abc", "https://example.com/", "https://example.com/path/", "/usr/local/share/doc/"}
+
+	data := make([]string, n)
+	for i := range data {
+		b := make([]byte, rand.Intn(12))
+		for j := range b {
+			b[j] = byte('a' + rand.Intn(4))
+		}
+		data[i] = prefixes[rand.Intn(len(prefixes))] + string(b)
+	}
+	return data
+}
+
+// testLCP checks that lcp is the LCP array of the sorted slice s.
+func testLCP[E string | []byte](t *testing.T, s []E, lcp []int) {
+	t.Helper()
+
+	if len(lcp) != len(s) {
+		t.Fatalf("len(lcp) = %d; want %d", len(lcp), len(s))
+	}
+	for i := range s {
+		want := 0
+		if i > 0 {
+			for want < min(len(s[i-1]), len(s[i])) && s[i-1][want] == s[i][want] {
+				want++
+			}
+		}
+		if lcp[i] != want {
+			t.Fatalf("lcp[%d] = %d; want %d (%q, %q)", i, lcp[i], want, s[max(i-1, 0)], s[i])
+		}
+	}
+}
+
+func TestMultikeyStrings_EmptyNil(t *testing.T) {
+	sort.MultikeyStrings([]string{})
+	sort.MultikeyStrings([]string(nil))
+	if lcp := sort.MultikeyStringsLCP([]string(nil)); len(lcp) != 0 {
+		t.Errorf("MultikeyStringsLCP(nil) = %v; want empty", lcp)
+	}
+}
+
+func TestMultikeyStrings(t *testing.T) {
+	data := multikeyData(10000)
+	want := slices.Clone(data)
+	slices.Sort(want)
+
+	sort.MultikeyStrings(data)
+
+	if !slices.Equal(data, want) {
+		t.Errorf("MultikeyStrings didn't sort %d strings", len(data))
+	}
+}
+
+func TestMultikeyStringsLCP(t *testing.T) {
+	data := multikeyData(10000)
+	want := slices.Clone(data)
+	slices.Sort(want)
+
+	lcp := sort.MultikeyStringsLCP(data)
+
+	if !slices.Equal(data, want) {
+		t.Errorf("MultikeyStringsLCP didn't sort %d strings", len(data))
+	}
+	testLCP(t, data, lcp)
+}
+
+func TestMultikeyBytes(t *testing.T) {
+	strs := multikeyData(10000)
+	data := make([][]byte, len(strs))
+	for i, s := range strs {
+		data[i] = []byte(s)
+	}
+	want := slices.Clone(data)
+	slices.SortFunc(want, bytes.Compare)
+
+	lcp := sort.MultikeyBytesLCP(data)
+
+	if !slices.EqualFunc(data, want, bytes.Equal) {
+		t.Errorf("MultikeyBytesLCP didn't sort %d byte slices", len(data))
+	}
+	testLCP(t, data, lcp)
+
+	rand.Shuffle(len(data), func(i, j int) { data[i], data[j] = data[j], data[i] })
+	sort.MultikeyBytes(data)
+
+	if !slices.EqualFunc(data, want, bytes.Equal) {
+		t.Errorf("MultikeyBytes didn't sort %d byte slices", len(data))
+	}
+}
+
+func TestMultikeyStrings_Equal(t *testing.T) {
+	data := make([]string, 1000)
+	for i := range data {
+		data[i] = strings.Repeat("x", i%3)
+	}
+
+	lcp := sort.MultikeyStringsLCP(data)
+
+	if !slices.IsSorted(data) {
+		t.Errorf("MultikeyStringsLCP didn't sort %d strings", len(data))
+	}
+	testLCP(t, data, lcp)
+}
+
+func benchmarkMultikey(b *testing.B, n int, sortFunc func([]string)) {
+	b.StopTimer()
+	data := multikeyData(n)
+	for i := 0; i < b.N; i++ {
+		s := slices.Clone(data)
+		b.StartTimer()
+		sortFunc(s)
+		b.StopTimer()
+	}
+}
+
+func BenchmarkMultikeyStrings64K(b *testing.B) {
+	benchmarkMultikey(b, 1<<16, sort.MultikeyStrings[[]string])
+}
+
+func BenchmarkMultikeyStringsLCP64K(b *testing.B) {
+	benchmarkMultikey(b, 1<<16, func(s []string) { sort.MultikeyStringsLCP(s) })
+}
+
+func BenchmarkRadixStrings64K(b *testing.B) {
+	benchmarkMultikey(b, 1<<16, sort.RadixStrings[[]string])
+}
+
+func BenchmarkSlicesSortStrings64K(b *testing.B) {
+	benchmarkMultikey(b, 1<<16, slices.Sort[[]string])
+}
+
+func FuzzMultikeyStringsLCP(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string) {
+		x := make([]string, 0)
+		for i := 0; i < len(s); i++ {
+			x = append(x, s[i:])
+			x = append(x, s[:i])
+		}
+
+		lcp := sort.MultikeyStringsLCP(x)
+
+		if !slices.IsSorted(x) {
+			t.Errorf("slice was not sorted")
+		}
+		testLCP(t, x, lcp)
+	})
+}
@@ -0,0 +1,307 @@
+package sort
+
+import (
+	"cmp"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// MaxSmall is the maximum length of a slice sorted by [Small] and [SmallFunc] using a sorting network.
+const MaxSmall = 16
+
+// Comparator is a comparator of a sorting network.
+// It compares the elements at indexes I and J, where I < J, and swaps them if they are out of order.
+type Comparator struct {
+	I, J int
+}
+
+// Network is a sorting network: a list of comparators applied in order.
+// The sequence of comparisons doesn't depend on the input, which makes sorting networks
+// free of unpredictable branches and suitable for parallel execution.
+type Network []Comparator
+
+// String returns the comparators of the network in the format [(0,1),(2,3),...].
+func (nw Network) String() string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, c := range nw {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "(%d,%d)", c.I, c.J)
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// Layers splits the network into layers of comparators that can be applied in parallel,
+// placing each comparator into the earliest possible layer.
+// The number of layers is the depth of the network.
+func (nw Network) Layers() []Network {
+	var layers []Network
+	depth := make(map[int]int) // number of layers used by each index
+	for _, c := range nw {
+		d := max(depth[c.I], depth[c.J])
+		if d == len(layers) {
+			layers = append(layers, nil)
+		}
+		layers[d] = append(layers[d], c)
+		depth[c.I], depth[c.J] = d+1, d+1
+	}
+	return layers
+}
+
+// NetworkFunc sorts the slice by applying the sorting network using a custom comparison function.
+// The indexes of the comparators must be less than len(s).
+// The sort is not guaranteed to be stable.
+func NetworkFunc[S ~[]E, E any](s S, nw Network, cmp func(a, b E) int) {
+	for _, c := range nw {
+		a, b := s[c.I], s[c.J]
+		if cmp(b, a) < 0 {
+			a, b = b, a
+		}
+		s[c.I], s[c.J] = a, b
+	}
+}
+
+// Small sorts a slice of at most [MaxSmall] elements using the best known sorting network for its length.
+// Longer slices are sorted using insertion sort.
+// The sort is not guaranteed to be stable.
+func Small[S ~[]E, E cmp.Ordered](s S) {
+	if len(s) > MaxSmall {
+		InsertionFunc(s, cmp.Compare[E])
+		return
+	}
+
+	for _, c := range smallNetworks[len(s)] {
+		a, b := s[c.I], s[c.J]
+		if cmp.Less(b, a) {
+			a, b = b, a
+		}
+		s[c.I], s[c.J] = a, b
+	}
+}
+
+// SmallFunc sorts a slice of at most [MaxSmall] elements using the best known sorting network for its length
+// and a custom comparison function.
+// Longer slices are sorted using [InsertionFunc].
+// The sort is not guaranteed to be stable.
+func SmallFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	if len(s) > MaxSmall {
+		InsertionFunc(s, cmp)
+		return
+	}
+	NetworkFunc(s, smallNetworks[len(s)], cmp)
+}
+
+// SmallNetwork returns the best known sorting network for n elements, the one used by [SmallFunc].
+// The networks for n <= 12 are proven to have the minimal number of comparators.
+// It panics if n is negative or greater than [MaxSmall].
+func SmallNetwork(n int) Network {
+	if n < 0 || n > MaxSmall {
+		panic("sort: network length out of range")
+	}
+	return append(Network(nil), smallNetworks[n]...)
+}
+
+// OddEvenMergeNetwork returns Batcher's odd-even merge sorting network for n elements.
+// It has O(n*log(n)^2) comparators and depth O(log(n)^2).
+// For n that is not a power of 2, the network for the next power of 2 is built
+// and the comparators of the elements with indexes n and above are dropped.
+// It panics if n is negative.
+func OddEvenMergeNetwork(n int) Network {
+	if n < 0 {
+		panic("sort: network length out of range")
+	}
+
+	var nw Network
+	m := networkPow2(n)
+	for p := 1; p < m; p *= 2 {
+		for k := p; k >= 1; k /= 2 {
+			for j := k % p; j+k < m; j += 2 * k {
+				for i := 0; i < min(k, m-j-k); i++ {
+					// Only merge the elements of the same pair of sorted blocks of length p.
+					if lo, hi := i+j, i+j+k; lo/(2*p) == hi/(2*p) && hi < n {
+						nw = append(nw, Comparator{lo, hi})
+					}
+				}
+			}
+		}
+	}
+	return nw
+}
+
+// BitonicNetwork returns Batcher's bitonic sorting network for n elements.
+// It has O(n*log(n)^2) comparators and depth O(log(n)^2).
+// The first step of each merge compares the elements symmetric around the middle of the block,
+// so that all comparators put the smaller element at the lower index.
+// For n that is not a power of 2, the network for the next power of 2 is built
+// and the comparators of the elements with indexes n and above are dropped.
+// It panics if n is negative.
+func BitonicNetwork(n int) Network {
+	if n < 0 {
+		panic("sort: network length out of range")
+	}
+
+	var nw Network
+	m := networkPow2(n)
+	for k := 2; k <= m; k *= 2 {
+		for j := k / 2; j >= 1; j /= 2 {
+			for i := 0; i < m; i++ {
+				l := i ^ j
+				if j == k/2 {
+					l = i ^ (k - 1)
+				}
+				if i < l && l < n {
+					nw = append(nw, Comparator{i, l})
+				}
+			}
+		}
+	}
+	return nw
+}
+
+// networkPow2 returns the smallest power of 2 greater than or equal to n.
+func networkPow2(n int) int {
+	if n <= 1 {
+		return n
+	}
+	return 1 << bits.Len(uint(n-1))
+}
+
+// smallNetworks holds the best known sorting networks for up to MaxSmall elements.
+// See https://bertdobbelaere.github.io/sorting_networks.html.
+var smallNetworks = [MaxSmall + 1]Network{
+	2: { // 1 comparator, depth 1
+		{0, 1},
+	},
+	3: { // 3 comparators, depth 3
+		{0, 2},
+		{0, 1},
+		{1, 2},
+	},
+	4: { // 5 comparators, depth 3
+		{0, 2}, {1, 3},
+		{0, 1}, {2, 3},
+		{1, 2},
+	},
+	5: { // 9 comparators, depth 5
+		{0, 3}, {1, 4},
+		{0, 2}, {1, 3},
+		{0, 1}, {2, 4},
+		{1, 2}, {3, 4},
+		{2, 3},
+	},
+	6: { // 12 comparators, depth 5
+		{0, 5}, {1, 3}, {2, 4},
+		{1, 2}, {3, 4},
+		{0, 3}, {2, 5},
+		{0, 1}, {2, 3}, {4, 5},
+		{1, 2}, {3, 4},
+	},
+	7: { // 16 comparators, depth 6
+		{0, 6}, {2, 3}, {4, 5},
+		{0, 2}, {1, 4}, {3, 6},
+		{0, 1}, {2, 5}, {3, 4},
+		{1, 2}, {4, 6},
+		{2, 3}, {4, 5},
+		{1, 2}, {3, 4}, {5, 6},
+	},
+	8: { // 19 comparators, depth 6
+		{0, 2}, {1, 3}, {4, 6}, {5, 7},
+		{0, 4}, {1, 5}, {2, 6}, {3, 7},
+		{0, 1}, {2, 3}, {4, 5}, {6, 7},
+		{2, 4}, {3, 5},
+		{1, 4}, {3, 6},
+		{1, 2}, {3, 4}, {5, 6},
+	},
+	9: { // 25 comparators, depth 7
+		{0, 3}, {1, 7}, {2, 5}, {4, 8},
+		{0, 7}, {2, 4}, {3, 8}, {5, 6},
+		{0, 2}, {1, 3}, {4, 5}, {7, 8},
+		{1, 4}, {3, 6}, {5, 7},
+		{0, 1}, {2, 4}, {3, 5}, {6, 8},
+		{2, 3}, {4, 5}, {6, 7},
+		{1, 2}, {3, 4}, {5, 6},
+	},
+	10: { // 29 comparators, depth 8
+		{0, 8}, {1, 9}, {2, 7}, {3, 5}, {4, 6},
+		{0, 2}, {1, 4}, {5, 8}, {7, 9},
+		{0, 3}, {2, 4}, {5, 7}, {6, 9},
+		{0, 1}, {3, 6}, {8, 9},
+		{1, 5}, {2, 3}, {4, 8}, {6, 7},
+		{1, 2}, {3, 5}, {4, 6}, {7, 8},
+		{2, 3}, {4, 5}, {6, 7},
+		{3, 4}, {5, 6},
+	},
+	11: { // 35 comparators, depth 8
+		{0, 9}, {1, 6}, {2, 4}, {3, 7}, {5, 8},
+		{0, 1}, {3, 5}, {4, 10}, {6, 9}, {7, 8},
+		{1, 3}, {2, 5}, {4, 7}, {8, 10},
+		{0, 4}, {1, 2}, {3, 7}, {5, 9}, {6, 8},
+		{0, 1}, {2, 6}, {4, 5}, {7, 8}, {9, 10},
+		{2, 4}, {3, 6}, {5, 7}, {8, 9},
+		{1, 2}, {3, 4}, {5, 6}, {7, 8},
+		{2, 3}, {4, 5}, {6, 7},
+	},
+	12: { // 39 comparators, depth 9
+		{0, 8}, {1, 7}, {2, 6}, {3, 11}, {4, 10}, {5, 9},
+		{0, 1}, {2, 5}, {3, 4}, {6, 9}, {7, 8}, {10, 11},
+		{0, 2}, {1, 6}, {5, 10}, {9, 11},
+		{0, 3}, {1, 2}, {4, 6}, {5, 7}, {8, 11}, {9, 10},
+		{1, 4}, {3, 5}, {6, 8}, {7, 10},
+		{1, 3}, {2, 5}, {6, 9}, {8, 10},
+		{2, 3}, {4, 5}, {6, 7}, {8, 9},
+		{4, 6}, {5, 7},
+		{3, 4}, {5, 6}, {7, 8},
+	},
+	13: { // 45 comparators, depth 10
+		{0, 12}, {1, 10}, {2, 9}, {3, 7}, {5, 11}, {6, 8},
+		{1, 6}, {2, 3}, {4, 11}, {7, 9}, {8, 10},
+		{0, 4}, {1, 2}, {3, 6}, {7, 8}, {9, 10}, {11, 12},
+		{4, 6}, {5, 9}, {8, 11}, {10, 12},
+		{0, 5}, {3, 8}, {4, 7}, {6, 11}, {9, 10},
+		{0, 1}, {2, 5}, {6, 9}, {7, 8}, {10, 11},
+		{1, 3}, {2, 4}, {5, 6}, {9, 10},
+		{1, 2}, {3, 4}, {5, 7}, {6, 8},
+		{2, 3}, {4, 5}, {6, 7}, {8, 9},
+		{3, 4}, {5, 6},
+	},
+	14: { // 51 comparators, depth 10
+		{0, 13}, {1, 12}, {4, 8}, {5, 6}, {7, 11}, {9, 10},
+		{0, 5}, {1, 7}, {2, 9}, {3, 4}, {6, 13}, {11, 12},
+		{0, 1}, {2, 3}, {4, 5}, {6, 8}, {7, 9}, {10, 11}, {12, 13},
+		{0, 2}, {1, 3}, {4, 10}, {5, 11}, {6, 7}, {8, 9},
+		{1, 2}, {3, 12}, {4, 6}, {5, 7}, {8, 10}, {9, 11},
+		{1, 4}, {2, 6}, {5, 8}, {7, 10}, {9, 13},
+		{2, 4}, {3, 6}, {9, 12}, {11, 13},
+		{3, 5}, {6, 8}, {7, 9}, {10, 12},
+		{3, 4}, {5, 6}, {7, 8}, {9, 10}, {11, 12},
+		{6, 7}, {8, 9},
+	},
+	15: { // 56 comparators, depth 10
+		{0, 13}, {1, 12}, {3, 14}, {4, 8}, {5, 6}, {7, 11}, {9, 10},
+		{0, 5}, {1, 7}, {2, 9}, {3, 4}, {6, 13}, {8, 14}, {11, 12},
+		{0, 1}, {2, 3}, {4, 5}, {6, 8}, {7, 9}, {10, 11}, {12, 13},
+		{0, 2}, {1, 3}, {4, 10}, {5, 11}, {6, 7}, {8, 9}, {12, 14},
+		{1, 2}, {3, 12}, {4, 6}, {5, 7}, {8, 10}, {9, 11}, {13, 14},
+		{1, 4}, {2, 6}, {5, 8}, {7, 10}, {9, 13}, {11, 14},
+		{2, 4}, {3, 6}, {9, 12}, {11, 13},
+		{3, 5}, {6, 8}, {7, 9}, {10, 12},
+		{3, 4}, {5, 6}, {7, 8}, {9, 10}, {11, 12},
+		{6, 7}, {8, 9},
+	},
+	16: { // 60 comparators, depth 10
+		{0, 13}, {1, 12}, {2, 15}, {3, 14}, {4, 8}, {5, 6}, {7, 11}, {9, 10},
+		{0, 5}, {1, 7}, {2, 9}, {3, 4}, {6, 13}, {8, 14}, {10, 15}, {11, 12},
+		{0, 1}, {2, 3}, {4, 5}, {6, 8}, {7, 9}, {10, 11}, {12, 13}, {14, 15},
+		{0, 2}, {1, 3}, {4, 10}, {5, 11}, {6, 7}, {8, 9}, {12, 14}, {13, 15},
+		{1, 2}, {3, 12}, {4, 6}, {5, 7}, {8, 10}, {9, 11}, {13, 14},
+		{1, 4}, {2, 6}, {5, 8}, {7, 10}, {9, 13}, {11, 14},
+		{2, 4}, {3, 6}, {9, 12}, {11, 13},
+		{3, 5}, {6, 8}, {7, 9}, {10, 12},
+		{3, 4}, {5, 6}, {7, 8}, {9, 10}, {11, 12},
+		{6, 7}, {8, 9},
+	},
+}
@@ -0,0 +1,210 @@
+package sort_test
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+// verifyNetwork reports whether the network sorts all inputs of length n, using the 0-1 principle:
+// a comparator network sorts all inputs if and only if it sorts all 2^n inputs of zeros and ones.
+// Each input is represented by the bits of an integer, the bit i being the element with index i.
+func verifyNetwork(nw sort.Network, n int) bool {
+	for _, c := range nw {
+		if c.I < 0 || c.I >= c.J || c.J >= n {
+			return false
+		}
+	}
+
+	for x := uint32(0); x < 1<<n; x++ {
+		v := x
+		for _, c := range nw {
+			// Swap a one at index I with a zero at index J.
+			if v>>c.I&1 == 1 && v>>c.J&1 == 0 {
+				v ^= 1<<c.I | 1<<c.J
+			}
+		}
+
+		// A sorted input has all the zeros before all the ones.
+		if v&(v+(v&-v)) != 0 || v != 0 && v>>(n-1)&1 == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestVerifyNetwork(t *testing.T) {
+	if !verifyNetwork(sort.Network{{0, 1}, {1, 2}, {0, 1}}, 3) {
+		t.Errorf("verifyNetwork rejected a sorting network")
+	}
+	if verifyNetwork(sort.Network{{0, 1}, {1, 2}}, 3) {
+		t.Errorf("verifyNetwork accepted a non-sorting network")
+	}
+	if verifyNetwork(sort.Network{{0, 2}, {0, 1}, {1, 2}}[:2], 3) {
+		t.Errorf("verifyNetwork accepted a non-sorting network")
+	}
+}
+
+func TestSmallNetwork(t *testing.T) {
+	sizes := []int{0, 0, 1, 3, 5, 9, 12, 16, 19, 25, 29, 35, 39, 45, 51, 56, 60}
+	for n := 0; n <= sort.MaxSmall; n++ {
+		nw := sort.SmallNetwork(n)
+		if len(nw) != sizes[n] {
+			t.Errorf("SmallNetwork(%d) has %d comparators; want %d", n, len(nw), sizes[n])
+		}
+		if !verifyNetwork(nw, n) {
+			t.Errorf("SmallNetwork(%d) = %v doesn't sort", n, nw)
+		}
+	}
+}
+
+func TestOddEvenMergeNetwork(t *testing.T) {
+	for n := 0; n <= 16; n++ {
+		if nw := sort.OddEvenMergeNetwork(n); !verifyNetwork(nw, n) {
+			t.Errorf("OddEvenMergeNetwork(%d) = %v doesn't sort", n, nw)
+		}
+	}
+	if n := 16; len(sort.OddEvenMergeNetwork(n)) != 63 {
+		t.Errorf("OddEvenMergeNetwork(%d) has %d comparators; want 63", n, len(sort.OddEvenMergeNetwork(n)))
+	}
+	testNetworkRandom(t, sort.OddEvenMergeNetwork)
+}
+
+func TestBitonicNetwork(t *testing.T) {
+	for n := 0; n <= 16; n++ {
+		if nw := sort.BitonicNetwork(n); !verifyNetwork(nw, n) {
+			t.Errorf("BitonicNetwork(%d) = %v doesn't sort", n, nw)
+		}
+	}
+	if n := 16; len(sort.BitonicNetwork(n)) != 80 {
+		t.Errorf("BitonicNetwork(%d) has %d comparators; want 80", n, len(sort.BitonicNetwork(n)))
+	}
+	testNetworkRandom(t, sort.BitonicNetwork)
+}
+
+// testNetworkRandom checks the networks too large to verify exhaustively on random inputs.
+func testNetworkRandom(t *testing.T, network func(n int) sort.Network) {
+	for _, n := range []int{17, 100, 1000, 1024} {
+		nw := network(n)
+		for k := 0; k < 10; k++ {
+			data := make([]int, n)
+			for i := range data {
+				data[i] = rand.Intn(n)
+			}
+
+			sort.NetworkFunc(data, nw, cmp.Compare[int])
+
+			if !slices.IsSorted(data) {
+				t.Fatalf("network for %d elements didn't sort", n)
+			}
+		}
+	}
+}
+
+func TestNetwork_String(t *testing.T) {
+	nw := sort.SmallNetwork(4)
+	if s, want := nw.String(), "[(0,2),(1,3),(0,1),(2,3),(1,2)]"; s != want {
+		t.Errorf("String() = %q; want %q", s, want)
+	}
+}
+
+func TestNetwork_Layers(t *testing.T) {
+	depths := []int{0, 0, 1, 3, 3, 5, 5, 6, 6, 7, 8, 8, 9, 10, 10, 10, 10}
+	for n := 0; n <= sort.MaxSmall; n++ {
+		nw := sort.SmallNetwork(n)
+		layers := nw.Layers()
+		if len(layers) != depths[n] {
+			t.Errorf("SmallNetwork(%d) has depth %d; want %d", n, len(layers), depths[n])
+		}
+		if got := slices.Concat(layers...); len(got) != len(nw) {
+			t.Errorf("layers of SmallNetwork(%d) have %d comparators; want %d", n, len(got), len(nw))
+		}
+		for _, l := range layers {
+			seen := make(map[int]bool)
+			for _, c := range l {
+				if seen[c.I] || seen[c.J] {
+					t.Fatalf("layer %v of SmallNetwork(%d) uses an index twice", l, n)
+				}
+				seen[c.I], seen[c.J] = true, true
+			}
+		}
+	}
+}
+
+func TestSmallFunc(t *testing.T) {
+	for n := 0; n <= sort.MaxSmall+4; n++ {
+		for k := 0; k < 100; k++ {
+			data := make([]int, n)
+			for i := range data {
+				data[i] = rand.Intn(n + 1)
+			}
+			floats := make([]float64, n)
+			for i, v := range data {
+				floats[i] = float64(v)
+			}
+
+			sort.SmallFunc(data, cmp.Compare[int])
+			sort.Small(floats)
+
+			if !slices.IsSorted(data) {
+				t.Fatalf("SmallFunc didn't sort %d ints", n)
+			}
+			if !slices.IsSorted(floats) {
+				t.Fatalf("Small didn't sort %d floats", n)
+			}
+		}
+	}
+}
+
+func TestSmallFunc_Data(t *testing.T) {
+	testSortFuncData(t, sort.SmallFunc[[]int], cmp.Compare[int], insertionFuncData)
+}
+
+func TestSmallFunc_Reverse(t *testing.T) {
+	testSortFuncReverse(t, sort.SmallFunc[[]int], cmp.Compare[int], insertionFuncData)
+}
+
+func TestSmallNetwork_Panics(t *testing.T) {
+	for _, n := range []int{-1, sort.MaxSmall + 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("SmallNetwork(%d) didn't panic", n)
+				}
+			}()
+			sort.SmallNetwork(n)
+		}()
+	}
+}
+
+func BenchmarkSmallFunc(b *testing.B) {
+	for _, n := range []int{4, 8, 12, 16} {
+		for _, bm := range []struct {
+			name     string
+			sortFunc func([]int, func(a, b int) int)
+		}{
+			{"Small", func(s []int, _ func(a, b int) int) { sort.Small(s) }},
+			{"SmallFunc", sort.SmallFunc[[]int]},
+			{"InsertionFunc", sort.InsertionFunc[[]int]},
+		} {
+			b.Run(fmt.Sprintf("%s/%d", bm.name, n), func(b *testing.B) {
+				data := make([]int, n*1024)
+				for i := range data {
+					data[i] = rand.Int()
+				}
+				s := make([]int, len(data))
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					copy(s, data)
+					for j := 0; j < len(s); j += n {
+						bm.sortFunc(s[j:j+n], cmp.Compare[int])
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package sort
+
+import (
+	"runtime"
+	"sync"
+)
+
+// DefaultParallelMergeThreshold is the default length of a subslice below which [ParallelMergeFunc]
+// sorts and merges sequentially.
+const DefaultParallelMergeThreshold = 1 << 13
+
+// ParallelMergeFunc implements parallel top-down merge sort using custom comparison function.
+// The halves are sorted concurrently and then merged in parallel by splitting the output into equal segments,
+// each merged independently, so that the final merge is not a serial bottleneck.
+//
+// At most procs goroutines run at a time; if procs <= 0, runtime.GOMAXPROCS(0) is used.
+// Subslices shorter than threshold are sorted and merged sequentially; if threshold <= 0, [DefaultParallelMergeThreshold] is used.
+// The cmp function must be safe for concurrent use.
+// The sort is stable.
+func ParallelMergeFunc[S ~[]E, E any](s S, cmp func(a, b E) int, procs, threshold int) {
+	parallelMergeSortFunc(s, cmp, procs, threshold, nil)
+}
+
+func parallelMergeSortFunc[E any](s []E, cmp func(a, b E) int, procs, threshold int, p *probe) {
+	if procs <= 0 {
+		procs = runtime.GOMAXPROCS(0)
+	}
+	if threshold <= 0 {
+		threshold = DefaultParallelMergeThreshold
+	}
+
+	p.alloc(len(s))
+	parallelMergeSort(s, cmp, make([]E, len(s)), procs, threshold, p)
+}
+
+// parallelMergeSort sorts s using at most procs goroutines and aux as a scratch space.
+func parallelMergeSort[E any](s []E, cmp func(a, b E) int, aux []E, procs, threshold int, p *probe) {
+	n := len(s)
+	if procs <= 1 || n <= threshold {
+		mergeSort(s, cmp, aux, p)
+		return
+	}
+
+	p.enter()
+	defer p.leave()
+
+	m := n / 2
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func(p *probe) {
+		defer wg.Done()
+		parallelMergeSort(s[:m], cmp, aux[:m], procs/2, threshold, p)
+	}(p.fork())
+	parallelMergeSort(s[m:], cmp, aux[m:], procs-procs/2, threshold, p)
+	wg.Wait()
+
+	parallelMerge(s[:m], s[m:], cmp, aux[:n], procs, threshold, p)
+
+	copy(s, aux)
+	p.move(n)
+}
+
+// parallelMerge merges two sorted slices s1 and s2 into one sorted slice aux using at most procs goroutines.
+// The output is split into segments and the boundaries of each segment in s1 and s2 are found using co-ranking (merge path).
+func parallelMerge[E any](s1, s2 []E, cmp func(a, b E) int, aux []E, procs, threshold int, p *probe) {
+	n := len(s1) + len(s2)
+
+	w := min(procs, n/threshold) // number of segments merged in parallel
+	if w <= 1 {
+		merge(s1, s2, cmp, aux, p)
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(w)
+
+	i0, j0, k0 := 0, 0, 0
+	for i := 1; i <= w; i++ {
+		k1 := n * i / w
+		i1 := coRank(k1, s1, s2, cmp)
+		j1 := k1 - i1
+
+		go func(s1, s2, aux []E) {
+			defer wg.Done()
+			merge(s1, s2, cmp, aux, p)
+		}(s1[i0:i1], s2[j0:j1], aux[k0:k1])
+
+		i0, j0, k0 = i1, j1, k1
+	}
+
+	wg.Wait()
+}
+
+// coRank returns the number of elements of s1 among the first k elements of the stable merge of sorted slices s1 and s2.
+// The number of elements of s2 is then k minus the returned value.
+func coRank[E any](k int, s1, s2 []E, cmp func(a, b E) int) int {
+	lo, hi := max(0, k-len(s2)), min(k, len(s1))
+
+	// Find the smallest i such that s1[i] > s2[k-i-1], as equal elements are taken from s1 first.
+	for lo < hi {
+		i := int(uint(lo+hi) >> 1)
+		if cmp(s1[i], s2[k-i-1]) <= 0 {
+			lo = i + 1
+		} else {
+			hi = i
+		}
+	}
+	return lo
+}
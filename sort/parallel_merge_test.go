@@ -0,0 +1,68 @@
+package sort_test
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+var parallelMergeFuncData = []int{74, 59, 238, -784, 9845, 959, 905, 0, 0, 42, 7586, -5467984, 7586}
+
+// parallelMergeFunc forces ParallelMergeFunc to run in parallel even on tiny slices.
+func parallelMergeFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	sort.ParallelMergeFunc(s, cmp, 4, 2)
+}
+
+func TestParallelMergeFunc_EmptyNil(t *testing.T) {
+	testSortFuncEmptyNil(t, parallelMergeFunc[[]int], cmp.Compare[int])
+}
+
+func TestParallelMergeFunc_Data(t *testing.T) {
+	testSortFuncData(t, parallelMergeFunc[[]int], cmp.Compare[int], parallelMergeFuncData)
+}
+
+func TestParallelMergeFunc_Reverse(t *testing.T) {
+	testSortFuncReverse(t, parallelMergeFunc[[]int], cmp.Compare[int], parallelMergeFuncData)
+}
+
+func TestParallelMergeFunc_RandomInts(t *testing.T) {
+	testSortFuncRandomInts(t, parallelMergeFunc[[]int], cmp.Compare[int])
+}
+
+func TestParallelMergeFunc_Patterns(t *testing.T) {
+	testSortFuncPatterns(t, parallelMergeFunc[[]int], cmp.Compare[int])
+}
+
+func TestParallelMergeFunc_Stability(t *testing.T) {
+	n, m := 100000, 1000
+	if testing.Short() {
+		n, m = 1000, 100
+	}
+
+	t.Run("Parallel", func(t *testing.T) {
+		testSortFuncStability(t, parallelMergeFunc[intPairs], n, m)
+	})
+	t.Run("Default", func(t *testing.T) {
+		testSortFuncStability(t, func(s intPairs, cmp func(a, b intPair) int) {
+			sort.ParallelMergeFunc(s, cmp, 0, 0)
+		}, n, m)
+	})
+}
+
+func BenchmarkParallelMergeFuncRandom1M(b *testing.B) {
+	benchmarkSortFuncRandom(b, 1<<20, func(s []int, cmp func(a, b int) int) {
+		sort.ParallelMergeFunc(s, cmp, 0, 0)
+	}, cmp.Compare[int])
+}
+
+func FuzzParallelMergeFunc(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s []byte) {
+		parallelMergeFunc(s, cmp.Compare)
+
+		if !slices.IsSortedFunc(s, cmp.Compare) {
+			t.Errorf("slice was not sorted")
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package sort
+
+import "github.com/denpeshkov/algorithms/partition"
+
+// nthInsertionCutoff is the length of a subslice below which insertion sort is used to select the element.
+const nthInsertionCutoff = 12
+
+// NthElementFunc reorders the slice in such a way that the element at index n is the one that would be there if the slice was sorted,
+// all elements before it are less than or equal to it and all elements after it are greater than or equal to it.
+// It implements introselect: quickselect with median-of-three pivots that falls back to median-of-medians pivots
+// if the partitions don't shrink fast enough, which guarantees O(n) time in the worst case.
+// Elements equal to the pivot are partitioned using [partition.ThreeWay], so slices with many duplicates are handled efficiently.
+// It panics if n is out of range.
+func NthElementFunc[S ~[]E, E any](s S, n int, cmp func(a, b E) int) {
+	if n < 0 || n >= len(s) {
+		panic("sort: index out of range")
+	}
+
+	nthElement(s, n, false, cmp)
+}
+
+// PartialSortFunc reorders the slice in such a way that the smallest k elements are sorted at the beginning of the slice.
+// The order of the remaining elements is unspecified.
+// If k >= len(s), the whole slice is sorted.
+// It runs in O(n+k*log(k)) time.
+// The sort is not guaranteed to be stable.
+func PartialSortFunc[S ~[]E, E any](s S, k int, cmp func(a, b E) int) {
+	if k <= 0 {
+		return
+	}
+	if k < len(s) {
+		nthElement(s, k-1, false, cmp)
+		s = s[:k-1]
+	}
+	PdqFunc(s, cmp)
+}
+
+// nthElement implements introselect.
+// If guaranteed is true, median-of-medians pivots are used from the start.
+func nthElement[E any](s []E, n int, guaranteed bool, cmp func(a, b E) int) {
+	// Median-of-three pivots must halve the slice every two iterations, otherwise median-of-medians is used.
+	checkpoint, iter := len(s), 0
+
+	for len(s) > nthInsertionCutoff {
+		var p int
+		if guaranteed {
+			p = medianOfMedians(s, cmp)
+		} else {
+			var swaps int
+			p = median(s, 0, len(s)/2, len(s)-1, &swaps, cmp)
+		}
+
+		pivot := s[p]
+		lt, gt := partition.ThreeWay(s, func(e E) int { return cmp(e, pivot) })
+
+		switch {
+		case n < lt:
+			s = s[:lt]
+		case n > gt:
+			s, n = s[gt+1:], n-gt-1
+		default:
+			return
+		}
+
+		if iter++; iter%2 == 0 {
+			if len(s) > checkpoint/2 {
+				guaranteed = true
+			}
+			checkpoint = len(s)
+		}
+	}
+
+	InsertionFunc(s, cmp)
+}
+
+// medianOfMedians returns the index of an approximate median of the slice,
+// which is guaranteed to be greater than at least 30% and less than at least 30% of the elements.
+// The medians of groups of 5 elements are moved to the beginning of the slice, and their median is selected recursively.
+func medianOfMedians[E any](s []E, cmp func(a, b E) int) int {
+	n := len(s)
+	if n < 5 {
+		InsertionFunc(s, cmp)
+		return n / 2
+	}
+
+	m := 0
+	for i := 0; i+5 <= n; i += 5 {
+		InsertionFunc(s[i:i+5], cmp)
+		s[m], s[i+2] = s[i+2], s[m]
+		m++
+	}
+
+	nthElement(s[:m], m/2, true, cmp)
+	return m / 2
+}
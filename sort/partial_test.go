@@ -0,0 +1,137 @@
+package sort_test
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+func testNthElement(t *testing.T, data []int, n int) {
+	t.Helper()
+
+	want := slices.Clone(data)
+	slices.Sort(want)
+	got := slices.Clone(data)
+
+	sort.NthElementFunc(got, n, cmp.Compare[int])
+
+	if got[n] != want[n] {
+		t.Fatalf("got s[%d] = %d; want %d", n, got[n], want[n])
+	}
+	for i := 0; i < n; i++ {
+		if got[i] > got[n] {
+			t.Fatalf("got s[%d] = %d > s[%d] = %d", i, got[i], n, got[n])
+		}
+	}
+	for i := n + 1; i < len(got); i++ {
+		if got[i] < got[n] {
+			t.Fatalf("got s[%d] = %d < s[%d] = %d", i, got[i], n, got[n])
+		}
+	}
+}
+
+func TestNthElementFunc(t *testing.T) {
+	for _, l := range []int{1, 2, 5, 12, 13, 100} {
+		data := make([]int, l)
+		for i := range data {
+			data[i] = rand.Intn(l)
+		}
+
+		for n := 0; n < l; n++ {
+			t.Run(fmt.Sprintf("len=%d,n=%d", l, n), func(t *testing.T) {
+				testNthElement(t, data, n)
+			})
+		}
+	}
+}
+
+func TestNthElementFunc_Patterns(t *testing.T) {
+	n := 10000
+	if testing.Short() {
+		n = 1000
+	}
+
+	for name, p := range sortPatterns(n) {
+		t.Run(name, func(t *testing.T) {
+			data := make([]int, n)
+			for i := range data {
+				data[i] = p(i)
+			}
+
+			for _, k := range []int{0, 1, n / 10, n / 2, n - 2, n - 1} {
+				testNthElement(t, data, k)
+			}
+		})
+	}
+}
+
+func TestNthElementFunc_Panic(t *testing.T) {
+	for _, n := range []int{-1, 3} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("got no panic; want panic")
+				}
+			}()
+
+			sort.NthElementFunc([]int{1, 2, 3}, n, cmp.Compare[int])
+		})
+	}
+}
+
+func TestPartialSortFunc(t *testing.T) {
+	n := 1000
+	data := make([]int, n)
+	for i := range data {
+		data[i] = rand.Intn(n / 2)
+	}
+	want := slices.Clone(data)
+	slices.Sort(want)
+
+	for _, k := range []int{-1, 0, 1, 2, 10, 500, 999, 1000, 2000} {
+		t.Run(fmt.Sprint(k), func(t *testing.T) {
+			got := slices.Clone(data)
+
+			sort.PartialSortFunc(got, k, cmp.Compare[int])
+
+			k := max(0, min(k, n))
+			if !slices.Equal(got[:k], want[:k]) {
+				t.Errorf("got %v; want %v", got[:k], want[:k])
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, want) {
+				t.Errorf("got elements lost or duplicated")
+			}
+		})
+	}
+}
+
+func BenchmarkNthElementFuncRandom1M(b *testing.B) {
+	benchmarkSortFuncRandom(b, 1<<20, func(s []int, cmp func(a, b int) int) {
+		sort.NthElementFunc(s, len(s)/2, cmp)
+	}, cmp.Compare[int])
+}
+
+func BenchmarkPartialSortFuncRandom1M(b *testing.B) {
+	benchmarkSortFuncRandom(b, 1<<20, func(s []int, cmp func(a, b int) int) {
+		sort.PartialSortFunc(s, 100, cmp)
+	}, cmp.Compare[int])
+}
+
+func FuzzNthElementFunc(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s []byte, n uint) {
+		if len(s) == 0 {
+			return
+		}
+		x := make([]int, len(s))
+		for i, v := range s {
+			x[i] = int(v)
+		}
+
+		testNthElement(t, x, int(n%uint(len(x))))
+	})
+}
@@ -0,0 +1,302 @@
+// Based on https://github.com/golang/go/blob/master/src/slices/zsortanyfunc.go
+
+package sort
+
+import (
+	"cmp"
+	"math/bits"
+)
+
+const (
+	// pdqInsertionCutoff is the length of a subslice below which insertion sort is used.
+	pdqInsertionCutoff = 12
+	// pdqNintherThreshold is the length of a subslice starting from which the Tukey's ninther is used to choose a pivot.
+	pdqNintherThreshold = 50
+)
+
+// sortedHint is a hint about the order of the elements, gathered while choosing a pivot.
+type sortedHint int
+
+const (
+	unknownHint sortedHint = iota
+	increasingHint
+	decreasingHint
+)
+
+// Pdq implements pattern-defeating quicksort.
+// The sort is not guaranteed to be stable.
+func Pdq[S ~[]E, E cmp.Ordered](s S) {
+	PdqFunc(s, cmp.Compare[E])
+}
+
+// PdqFunc implements pattern-defeating quicksort using a custom comparison function.
+// It runs in O(n*log(n)) time in the worst case and doesn't allocate.
+// The sort is not guaranteed to be stable.
+func PdqFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	pdqFunc(s, cmp, nil)
+}
+
+func pdqFunc[E any](s []E, cmp func(a, b E) int, p *probe) {
+	n := len(s)
+	pdq(s, 0, n, bits.Len(uint(n)), cmp, p)
+}
+
+// pdq sorts s[a:b].
+// limit is the number of allowed bad (highly unbalanced) pivots before falling back to heapsort.
+func pdq[E any](s []E, a, b, limit int, cmp func(a, b E) int, p *probe) {
+	p.enter()
+	defer p.leave()
+
+	wasBalanced, wasPartitioned := true, true
+
+	for {
+		n := b - a
+
+		if n <= pdqInsertionCutoff {
+			insertion(s[a:b], cmp, p)
+			return
+		}
+
+		// Too many bad pivots, fall back to heapsort to guarantee O(n*log(n)).
+		if limit == 0 {
+			heapSort(s[a:b], cmp, p)
+			return
+		}
+
+		// The previous partitioning was unbalanced, shuffle some elements to break the pattern.
+		if !wasBalanced {
+			pdqBreakPatterns(s[a:b], p)
+			limit--
+		}
+
+		pivot, hint := pdqChoosePivot(s, a, b, cmp)
+		if hint == decreasingHint {
+			reverse(s[a:b], p)
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// The slice is likely already sorted, try to finish it off with a few insertion sort steps.
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if pdqPartialInsertion(s[a:b], cmp, p) {
+				return
+			}
+		}
+
+		// The predecessor s[a-1] was a pivot of the enclosing partition, so every element of s[a:b] is >= s[a-1].
+		// If the pivot is equal to the predecessor, put all elements equal to the pivot in place at once.
+		if a > 0 && cmp(s[a-1], s[pivot]) >= 0 {
+			a = pdqPartitionEqual(s, a, b, pivot, cmp, p)
+			continue
+		}
+
+		mid, alreadyPartitioned := pdqPartition(s, a, b, pivot, cmp, p)
+		wasPartitioned = alreadyPartitioned
+
+		// Recurse into the smaller side and loop over the larger one to bound the stack depth.
+		l, r := mid-a, b-mid
+		balanceThreshold := n / 8
+		if l < r {
+			wasBalanced = l >= balanceThreshold
+			pdq(s, a, mid, limit, cmp, p)
+			a = mid + 1
+		} else {
+			wasBalanced = r >= balanceThreshold
+			pdq(s, mid+1, b, limit, cmp, p)
+			b = mid
+		}
+	}
+}
+
+// pdqPartition partitions s[a:b] around the pivot s[pivot] and returns the new index of the pivot.
+// It also reports whether the slice was already partitioned, i.e. no elements were swapped.
+func pdqPartition[E any](s []E, a, b, pivot int, cmp func(a, b E) int, p *probe) (mid int, alreadyPartitioned bool) {
+	s[a], s[pivot] = s[pivot], s[a]
+	p.move(2)
+	i, j := a+1, b-1 // [i, j] are the elements yet to be partitioned
+
+	for i <= j && cmp(s[i], s[a]) < 0 {
+		i++
+	}
+	for i <= j && cmp(s[j], s[a]) >= 0 {
+		j--
+	}
+	if i > j {
+		s[j], s[a] = s[a], s[j]
+		p.move(2)
+		return j, true
+	}
+	s[i], s[j] = s[j], s[i]
+	p.move(2)
+	i++
+	j--
+
+	for {
+		for i <= j && cmp(s[i], s[a]) < 0 {
+			i++
+		}
+		for i <= j && cmp(s[j], s[a]) >= 0 {
+			j--
+		}
+		if i > j {
+			break
+		}
+		s[i], s[j] = s[j], s[i]
+		p.move(2)
+		i++
+		j--
+	}
+	s[j], s[a] = s[a], s[j]
+	p.move(2)
+	return j, false
+}
+
+// pdqPartitionEqual partitions s[a:b] into elements equal to the pivot s[pivot] followed by elements greater than it.
+// It assumes that s[a:b] contains no elements less than the pivot and returns the index of the first greater element.
+func pdqPartitionEqual[E any](s []E, a, b, pivot int, cmp func(a, b E) int, p *probe) int {
+	s[a], s[pivot] = s[pivot], s[a]
+	p.move(2)
+	i, j := a+1, b-1 // [i, j] are the elements yet to be partitioned
+
+	for {
+		for i <= j && cmp(s[a], s[i]) >= 0 {
+			i++
+		}
+		for i <= j && cmp(s[a], s[j]) < 0 {
+			j--
+		}
+		if i > j {
+			break
+		}
+		s[i], s[j] = s[j], s[i]
+		p.move(2)
+		i++
+		j--
+	}
+	return i
+}
+
+// pdqPartialInsertion partially sorts s by moving a few out-of-order elements into place.
+// It reports whether s ends up sorted.
+func pdqPartialInsertion[E any](s []E, cmp func(a, b E) int, p *probe) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short slices
+	)
+
+	n := len(s)
+	i := 1
+	for step := 0; step < maxSteps; step++ {
+		for i < n && cmp(s[i], s[i-1]) >= 0 {
+			i++
+		}
+		if i == n {
+			return true
+		}
+		if n < shortestShifting {
+			return false
+		}
+
+		s[i], s[i-1] = s[i-1], s[i]
+		p.move(2)
+
+		// Shift the smaller element to the left.
+		for j := i - 1; j > 0 && cmp(s[j], s[j-1]) < 0; j-- {
+			s[j], s[j-1] = s[j-1], s[j]
+			p.move(2)
+		}
+		// Shift the greater element to the right.
+		for j := i + 1; j < n && cmp(s[j], s[j-1]) < 0; j++ {
+			s[j], s[j-1] = s[j-1], s[j]
+			p.move(2)
+		}
+	}
+	return false
+}
+
+// pdqBreakPatterns swaps a few elements around the middle of s with pseudo-random positions
+// to defeat patterns that cause unbalanced partitions.
+func pdqBreakPatterns[E any](s []E, p *probe) {
+	n := len(s)
+	if n < 8 {
+		return
+	}
+
+	r := xorshift(n)
+	mask := uint(1)<<bits.Len(uint(n)) - 1
+
+	idx := n/4*2 - 1
+	for i := 0; i < 3; i++ {
+		other := int(uint(r.next()) & mask)
+		if other >= n {
+			other -= n
+		}
+		s[idx-1+i], s[other] = s[other], s[idx-1+i]
+		p.move(2)
+	}
+}
+
+// pdqChoosePivot chooses a pivot in s[a:b] and returns its index
+// along with a hint about the order of the elements inspected.
+// For short slices it uses the median of three, for longer ones the Tukey's ninther.
+func pdqChoosePivot[E any](s []E, a, b int, cmp func(a, b E) int) (pivot int, hint sortedHint) {
+	const maxSwaps = 4 * 3
+
+	n := b - a
+	swaps := 0
+	i, j, k := a+n/4*1, a+n/4*2, a+n/4*3
+
+	if n >= 8 {
+		if n >= pdqNintherThreshold {
+			i = median(s, i-1, i, i+1, &swaps, cmp)
+			j = median(s, j-1, j, j+1, &swaps, cmp)
+			k = median(s, k-1, k, k+1, &swaps, cmp)
+		}
+		j = median(s, i, j, k, &swaps, cmp)
+	}
+
+	switch swaps {
+	case 0:
+		return j, increasingHint
+	case maxSwaps:
+		return j, decreasingHint
+	default:
+		return j, unknownHint
+	}
+}
+
+// median returns the index of the median of s[a], s[b] and s[c].
+// swaps is incremented by the number of out-of-order pairs encountered.
+func median[E any](s []E, a, b, c int, swaps *int, cmp func(a, b E) int) int {
+	order := func(a, b int) (int, int) {
+		if cmp(s[b], s[a]) < 0 {
+			*swaps++
+			return b, a
+		}
+		return a, b
+	}
+
+	a, b = order(a, b)
+	b, c = order(b, c)
+	_, b = order(a, b)
+	return b
+}
+
+// reverse reverses the elements of s.
+func reverse[E any](s []E, p *probe) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+	p.move(len(s) / 2 * 2)
+}
+
+// xorshift is a xorshift pseudo-random number generator.
+// See https://en.wikipedia.org/wiki/Xorshift.
+type xorshift uint64
+
+func (r *xorshift) next() uint64 {
+	*r ^= *r << 13
+	*r ^= *r >> 7
+	*r ^= *r << 17
+	return uint64(*r)
+}
@@ -0,0 +1,59 @@
+package sort_test
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+var pdqFuncData = []int{74, 59, 238, -784, 9845, 959, 905, 0, 0, 42, 7586, -5467984, 7586}
+
+func TestPdqFunc_EmptyNil(t *testing.T) {
+	testSortFuncEmptyNil(t, sort.PdqFunc[[]int], cmp.Compare[int])
+}
+
+func TestPdqFunc_Data(t *testing.T) {
+	testSortFuncData(t, sort.PdqFunc[[]int], cmp.Compare[int], pdqFuncData)
+}
+
+func TestPdqFunc_Reverse(t *testing.T) {
+	testSortFuncReverse(t, sort.PdqFunc[[]int], cmp.Compare[int], pdqFuncData)
+}
+
+func TestPdqFunc_RandomInts(t *testing.T) {
+	testSortFuncRandomInts(t, sort.PdqFunc[[]int], cmp.Compare[int])
+}
+
+func TestPdqFunc_Patterns(t *testing.T) {
+	testSortFuncPatterns(t, sort.PdqFunc[[]int], cmp.Compare[int])
+}
+
+func TestPdq(t *testing.T) {
+	data := append([]int(nil), pdqFuncData...)
+
+	sort.Pdq(data)
+
+	if !slices.IsSorted(data) {
+		t.Errorf("got unsorted slice: %v, want sorted slice", data)
+	}
+}
+
+func BenchmarkPdqFunc1K(b *testing.B) {
+	benchmarkSortFunc1K(b, sort.PdqFunc[[]int], cmp.Compare[int])
+}
+
+func BenchmarkPdqFuncRandom1M(b *testing.B) {
+	benchmarkSortFuncRandom(b, 1<<20, sort.PdqFunc[[]int], cmp.Compare[int])
+}
+
+func FuzzPdqFunc(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s []byte) {
+		sort.PdqFunc(s, cmp.Compare)
+
+		if !slices.IsSortedFunc(s, cmp.Compare) {
+			t.Errorf("slice was not sorted")
+		}
+	})
+}
@@ -0,0 +1,199 @@
+package sort
+
+import (
+	"math"
+	"unsafe"
+)
+
+// radixInsertionCutoff is the length of a slice below which insertion sort is used instead of radix sort.
+const radixInsertionCutoff = 32
+
+// Integer is a constraint that permits any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is a constraint that permits any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// RadixInts implements LSD radix sort of integers.
+// It runs in O(n) time and requires O(n) auxiliary space.
+func RadixInts[S ~[]E, E Integer](s S) {
+	var zero E
+	width := int(unsafe.Sizeof(zero))
+	signed := ^zero < 0
+
+	// Flipping the sign bit maps signed integers to unsigned ones preserving the order.
+	var mask, sign uint64 = math.MaxUint64 >> (64 - 8*width), 0
+	if signed {
+		sign = 1 << (8*width - 1)
+	}
+
+	keys := make([]uint64, len(s))
+	for i, v := range s {
+		keys[i] = uint64(v)&mask ^ sign
+	}
+	radixLSD(s, keys, width)
+}
+
+// RadixFloats implements LSD radix sort of floating-point numbers.
+// NaNs are ordered before any other values, which is consistent with [cmp.Compare], and -0 is ordered before +0.
+// It runs in O(n) time and requires O(n) auxiliary space.
+func RadixFloats[S ~[]E, E Float](s S) {
+	keys := make([]uint64, len(s))
+	for i, v := range s {
+		keys[i] = floatKey(float64(v))
+	}
+	radixLSD(s, keys, 8)
+}
+
+// RadixFunc implements LSD radix sort of elements by keys extracted using a key function.
+// The key is extracted once per element.
+// It runs in O(n) time and requires O(n) auxiliary space.
+// The sort is stable.
+func RadixFunc[S ~[]E, E any](s S, key func(E) uint64) {
+	keys := make([]uint64, len(s))
+	for i, v := range s {
+		keys[i] = key(v)
+	}
+	radixLSD(s, keys, 8)
+}
+
+// RadixStrings implements MSD radix sort of strings.
+// It runs in O(w*n) time, where w is the average length of the distinguishing prefixes of the strings,
+// and requires O(n) auxiliary space.
+func RadixStrings[S ~[]E, E ~string](s S) {
+	radixMSD(s, make([]E, len(s)), 0)
+}
+
+// floatKey maps a float to an unsigned integer preserving the order.
+// Negative floats have all bits flipped and non-negative ones have the sign bit flipped.
+// All NaNs are mapped to 0, the smallest key.
+func floatKey(f float64) uint64 {
+	if f != f {
+		return 0
+	}
+
+	b := math.Float64bits(f)
+	if b>>63 == 1 {
+		return ^b
+	}
+	return b | 1<<63
+}
+
+// radixLSD stably sorts s by the lowest width bytes of the corresponding keys, one byte per pass.
+func radixLSD[E any](s []E, keys []uint64, width int) {
+	n := len(s)
+
+	if n <= radixInsertionCutoff {
+		for i := 1; i < n; i++ {
+			v, k := s[i], keys[i]
+			j := i - 1
+			for j >= 0 && keys[j] > k {
+				s[j+1], keys[j+1] = s[j], keys[j]
+				j--
+			}
+			s[j+1], keys[j+1] = v, k
+		}
+		return
+	}
+
+	// Count the occurrences of each byte for all passes at once.
+	var counts [8][256]int
+	for _, k := range keys {
+		for p := 0; p < width; p++ {
+			counts[p][byte(k>>(8*p))]++
+		}
+	}
+
+	src, srcKeys := s, keys
+	dst, dstKeys := make([]E, n), make([]uint64, n)
+
+	for p := 0; p < width; p++ {
+		c := &counts[p]
+
+		// All keys share the same byte, the pass won't change the order.
+		if c[byte(srcKeys[0]>>(8*p))] == n {
+			continue
+		}
+
+		// Turn the counts into the starting indexes.
+		sum := 0
+		for i, v := range c {
+			c[i] = sum
+			sum += v
+		}
+
+		for i, k := range srcKeys {
+			b := byte(k >> (8 * p))
+			j := c[b]
+			dst[j], dstKeys[j] = src[i], k
+			c[b] = j + 1
+		}
+
+		src, dst = dst, src
+		srcKeys, dstKeys = dstKeys, srcKeys
+	}
+
+	if &src[0] != &s[0] {
+		copy(s, src)
+	}
+}
+
+// radixMSD sorts s by the bytes of the strings starting at index d, using aux as a scratch space.
+func radixMSD[E ~string](s, aux []E, d int) {
+	n := len(s)
+
+	if n <= radixInsertionCutoff {
+		for i := 1; i < n; i++ {
+			v := s[i]
+			j := i - 1
+			for j >= 0 && s[j][d:] > v[d:] {
+				s[j+1] = s[j]
+				j--
+			}
+			s[j+1] = v
+		}
+		return
+	}
+
+	// Bucket 0 holds strings ending before index d, bucket b+1 holds strings with byte b at index d.
+	var start [257]int
+	for _, v := range s {
+		start[radixBucket(v, d)]++
+	}
+
+	// Turn the counts into the starting indexes.
+	sum := 0
+	for i, v := range start {
+		start[i] = sum
+		sum += v
+	}
+
+	next := start
+	for _, v := range s {
+		b := radixBucket(v, d)
+		aux[next[b]] = v
+		next[b]++
+	}
+	copy(s, aux[:n])
+
+	// Strings in bucket 0 are all equal, the rest are sorted by the next byte.
+	for b := 1; b < len(start); b++ {
+		lo, hi := start[b], next[b]
+		if hi-lo > 1 {
+			radixMSD(s[lo:hi], aux, d+1)
+		}
+	}
+}
+
+// radixBucket returns the MSD radix sort bucket of the string v at index d.
+func radixBucket[E ~string](v E, d int) int {
+	if d >= len(v) {
+		return 0
+	}
+	return int(v[d]) + 1
+}
@@ -0,0 +1,180 @@
+package sort_test
+
+import (
+	"cmp"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+func testRadixInts[E sort.Integer](t *testing.T, gen func() E) {
+	t.Parallel()
+
+	for _, n := range []int{0, 1, 2, 10, 100, 1000, 10000} {
+		data := make([]E, n)
+		for i := range data {
+			data[i] = gen()
+		}
+		want := slices.Clone(data)
+		slices.Sort(want)
+
+		sort.RadixInts(data)
+
+		if !slices.Equal(data, want) {
+			t.Errorf("RadixInts didn't sort %d ints", n)
+		}
+	}
+}
+
+func TestRadixInts(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		testRadixInts(t, func() int { return int(rand.Uint64()) })
+	})
+	t.Run("int8", func(t *testing.T) {
+		testRadixInts(t, func() int8 { return int8(rand.Uint32()) })
+	})
+	t.Run("int16", func(t *testing.T) {
+		testRadixInts(t, func() int16 { return int16(rand.Uint32()) })
+	})
+	t.Run("int32 small", func(t *testing.T) {
+		testRadixInts(t, func() int32 { return int32(rand.Intn(200) - 100) })
+	})
+	t.Run("int64", func(t *testing.T) {
+		testRadixInts(t, func() int64 { return int64(rand.Uint64()) })
+	})
+	t.Run("uint8", func(t *testing.T) {
+		testRadixInts(t, func() uint8 { return uint8(rand.Uint32()) })
+	})
+	t.Run("uint32", func(t *testing.T) {
+		testRadixInts(t, func() uint32 { return rand.Uint32() })
+	})
+	t.Run("uint64", func(t *testing.T) {
+		testRadixInts(t, func() uint64 { return rand.Uint64() })
+	})
+	t.Run("uint64 small", func(t *testing.T) {
+		testRadixInts(t, func() uint64 { return uint64(rand.Intn(1000)) })
+	})
+}
+
+func TestRadixInts_EmptyNil(t *testing.T) {
+	testSortFuncEmptyNil(t, func(s []int, _ func(a, b int) int) { sort.RadixInts(s) }, cmp.Compare[int])
+}
+
+func TestRadixInts_Patterns(t *testing.T) {
+	testSortFuncPatterns(t, func(s []int, _ func(a, b int) int) { sort.RadixInts(s) }, cmp.Compare[int])
+}
+
+func TestRadixFloats(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+
+	tests := map[string]struct {
+		data []float64
+		want []float64
+	}{
+		"special": {
+			data: []float64{1, math.Inf(1), 0, math.NaN(), -1, negZero, math.Inf(-1), math.SmallestNonzeroFloat64, -math.MaxFloat64},
+			want: []float64{math.NaN(), math.Inf(-1), -math.MaxFloat64, -1, negZero, 0, math.SmallestNonzeroFloat64, 1, math.Inf(1)},
+		},
+		"zeros": {
+			data: []float64{0, negZero, 0, negZero},
+			want: []float64{negZero, negZero, 0, 0},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sort.RadixFloats(tt.data)
+
+			for i := range tt.data {
+				got, want := tt.data[i], tt.want[i]
+				if math.Float64bits(got) != math.Float64bits(want) && !(math.IsNaN(got) && math.IsNaN(want)) {
+					t.Fatalf("got %v; want %v", tt.data, tt.want)
+				}
+			}
+		})
+	}
+
+	t.Run("random", func(t *testing.T) {
+		data := make([]float32, 10000)
+		for i := range data {
+			data[i] = float32(rand.NormFloat64() * 1e6)
+		}
+
+		sort.RadixFloats(data)
+
+		if !slices.IsSorted(data) {
+			t.Errorf("RadixFloats didn't sort %d floats", len(data))
+		}
+	})
+}
+
+func TestRadixFunc_Stability(t *testing.T) {
+	n, m := 100000, 1000
+	if testing.Short() {
+		n, m = 1000, 100
+	}
+
+	radixFunc := func(s intPairs, _ func(a, b intPair) int) {
+		sort.RadixFunc(s, func(p intPair) uint64 { return uint64(p.a) })
+	}
+	testSortFuncStability(t, radixFunc, n, m)
+}
+
+func TestRadixStrings(t *testing.T) {
+	prefixes := []string{"", "a", "ab", "abc", "https://example.com/", "https://example.com/path/"}
+
+	data := make([]string, 10000)
+	for i := range data {
+		b := make([]byte, rand.Intn(8))
+		for j := range b {
+			b[j] = byte('a' + rand.Intn(4))
+		}
+		data[i] = prefixes[rand.Intn(len(prefixes))] + string(b)
+	}
+	want := slices.Clone(data)
+	slices.Sort(want)
+
+	sort.RadixStrings(data)
+
+	if !slices.Equal(data, want) {
+		t.Errorf("RadixStrings didn't sort %d strings", len(data))
+	}
+}
+
+func BenchmarkRadixIntsRandom1M(b *testing.B) {
+	benchmarkSortFuncRandom(b, 1<<20, func(s []int, _ func(a, b int) int) { sort.RadixInts(s) }, cmp.Compare[int])
+}
+
+func FuzzRadixInts(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s []byte) {
+		x := make([]int16, len(s)/2)
+		for i := range x {
+			x[i] = int16(s[2*i])<<8 | int16(s[2*i+1])
+		}
+
+		sort.RadixInts(x)
+
+		if !slices.IsSorted(x) {
+			t.Errorf("slice was not sorted")
+		}
+	})
+}
+
+func FuzzRadixStrings(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string) {
+		x := make([]string, 0)
+		for i := 0; i < len(s); i++ {
+			x = append(x, s[i:])
+			x = append(x, s[:i])
+		}
+
+		sort.RadixStrings(x)
+
+		if !slices.IsSorted(x) {
+			t.Errorf("slice was not sorted")
+		}
+	})
+}
@@ -2,14 +2,40 @@ package sort
 
 // SelectionFunc implements a selection sort using a custom comparison function.
 func SelectionFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	selection(s, cmp, nil)
+}
+
+func selection[E any](s []E, cmp func(a, b E) int, p *probe) {
+	if p.traced() {
+		selectionTraced(s, cmp, p)
+		return
+	}
+
+	n := len(s)
+	for i := 0; i < n; i++ {
+		min := i
+		for j := i + 1; j < n; j++ {
+			if cmp(s[j], s[min]) < 0 {
+				min = j
+			}
+		}
+		s[i], s[min] = s[min], s[i]
+		p.move(2)
+	}
+}
+
+// selectionTraced is the same as selection, but reports every operation to the probe.
+func selectionTraced[E any](s []E, cmp func(a, b E) int, p *probe) {
 	n := len(s)
 	for i := 0; i < n; i++ {
 		min := i
 		for j := i + 1; j < n; j++ {
+			p.compare(j, min)
 			if cmp(s[j], s[min]) < 0 {
 				min = j
 			}
 		}
 		s[i], s[min] = s[min], s[i]
+		p.swap(i, min)
 	}
 }
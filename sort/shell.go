@@ -0,0 +1,136 @@
+package sort
+
+import "math"
+
+// ciuraGaps is the gap sequence of [ShellFunc]. It's shared by all calls, so it's computed once up to the largest possible length.
+var ciuraGaps = CiuraGaps(math.MaxInt)
+
+// KnuthGaps returns the Knuth's gap sequence (3^k-1)/2 for sorting a slice of length n using [ShellGapsFunc]:
+// the gaps 1, 4, 13, 40, 121, ... less than n, or just 1 if n <= 1.
+func KnuthGaps(n int) []int {
+	return gapSequence(n, func(k int, prev float64) float64 { return 3*prev + 1 })
+}
+
+// SedgewickGaps returns the Sedgewick's gap sequence 4^k+3*2^(k-1)+1, prefixed with 1, for sorting a slice of length n using [ShellGapsFunc]:
+// the gaps 1, 8, 23, 77, 281, ... less than n, or just 1 if n <= 1.
+func SedgewickGaps(n int) []int {
+	return gapSequence(n, func(k int, prev float64) float64 { return math.Pow(4, float64(k)) + 3*math.Pow(2, float64(k-1)) + 1 })
+}
+
+// TokudaGaps returns the Tokuda's gap sequence ceil((9^k-4^k)/(5*4^(k-1))) for sorting a slice of length n using [ShellGapsFunc]:
+// the gaps 1, 4, 9, 20, 46, 103, ... less than n, or just 1 if n <= 1.
+func TokudaGaps(n int) []int {
+	return gapSequence(n, func(k int, prev float64) float64 { return 2.25*prev + 1 })
+}
+
+// CiuraGaps returns the Ciura's experimentally derived gap sequence 1, 4, 10, 23, 57, 132, 301, 701, 1750,
+// extended by multiplying the previous gap by 2.25, for sorting a slice of length n using [ShellGapsFunc]:
+// the gaps less than n, or just 1 if n <= 1.
+func CiuraGaps(n int) []int {
+	s := []int{1}
+	for _, g := range []int{4, 10, 23, 57, 132, 301, 701, 1750} {
+		if g >= n {
+			return s
+		}
+		s = append(s, g)
+	}
+	for g := 1750.0; ; {
+		g *= 2.25
+		if g >= float64(n) {
+			return s
+		}
+		s = append(s, int(g))
+	}
+}
+
+// ShellFunc implements Shellsort with the [CiuraGaps] gap sequence using a custom comparison function.
+// It doesn't allocate.
+// The sort is not guaranteed to be stable.
+func ShellFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	shell(s, ciuraGaps, cmp, nil)
+}
+
+// ShellGapsFunc implements Shellsort with a custom gap sequence using a custom comparison function.
+// The gap sequence must be in strictly increasing order and start with 1, such as [KnuthGaps](len(s)); it panics otherwise.
+// The gaps that are not less than the length of the slice are skipped.
+// It doesn't allocate.
+// The sort is not guaranteed to be stable.
+func ShellGapsFunc[S ~[]E, E any](s S, gaps []int, cmp func(a, b E) int) {
+	if len(gaps) == 0 || gaps[0] != 1 {
+		panic("sort: gap sequence must start with 1")
+	}
+	for k := 1; k < len(gaps); k++ {
+		if gaps[k] <= gaps[k-1] {
+			panic("sort: gap sequence must be strictly increasing")
+		}
+	}
+
+	shell(s, gaps, cmp, nil)
+}
+
+func shell[E any](s []E, gaps []int, cmp func(a, b E) int, p *probe) {
+	n := len(s)
+
+	// Skip the gaps that are not less than the length of the slice.
+	k := len(gaps) - 1
+	for k > 0 && gaps[k] >= n {
+		k--
+	}
+
+	if p.traced() {
+		shellTraced(s, gaps[:k+1], cmp, p)
+		return
+	}
+
+	// Insertion sort of the elements that are h apart.
+	for ; k >= 0; k-- {
+		h := gaps[k]
+		for i := h; i < n; i++ {
+			v := s[i]
+			j := i
+			for j >= h && cmp(s[j-h], v) > 0 {
+				s[j] = s[j-h]
+				j -= h
+			}
+			s[j] = v
+			p.move((i-j)/h + 1)
+		}
+	}
+}
+
+// shellTraced is the same as shell with the gaps less than the length of the slice, but reports every operation to the probe.
+func shellTraced[E any](s []E, gaps []int, cmp func(a, b E) int, p *probe) {
+	n := len(s)
+	for k := len(gaps) - 1; k >= 0; k-- {
+		h := gaps[k]
+		for i := h; i < n; i++ {
+			v := s[i]
+			j := i
+			for j >= h {
+				p.compare(j-h, j)
+				if cmp(s[j-h], v) <= 0 {
+					break
+				}
+				s[j] = s[j-h]
+				p.write(j)
+				j -= h
+			}
+			s[j] = v
+			p.write(j)
+		}
+	}
+}
+
+// gapSequence returns the increasing gap sequence starting with 1 of the gaps less than n,
+// where the k-th gap is the ceiling of next(k, previous gap before rounding).
+// See https://en.wikipedia.org/wiki/Shellsort#Gap_sequences.
+func gapSequence(n int, next func(k int, prev float64) float64) []int {
+	s := []int{1}
+	for k, g := 1, 1.0; ; k++ {
+		g = next(k, g)
+		if math.Ceil(g) >= float64(n) {
+			return s
+		}
+		s = append(s, int(math.Ceil(g)))
+	}
+}
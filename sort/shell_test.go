@@ -0,0 +1,134 @@
+package sort_test
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+var shellFuncData = []int{74, 59, 238, -784, 9845, 959, 905, 0, 0, 42, 7586, -5467984, 7586}
+
+var shellGaps = map[string]func(n int) []int{
+	"Knuth":     sort.KnuthGaps,
+	"Sedgewick": sort.SedgewickGaps,
+	"Tokuda":    sort.TokudaGaps,
+	"Ciura":     sort.CiuraGaps,
+	"Insertion": func(int) []int { return []int{1} },
+}
+
+func shellGapsFunc(gaps func(n int) []int) func([]int, func(a, b int) int) {
+	return func(s []int, cmp func(a, b int) int) {
+		sort.ShellGapsFunc(s, gaps(len(s)), cmp)
+	}
+}
+
+func TestShellFunc_EmptyNil(t *testing.T) {
+	testSortFuncEmptyNil(t, sort.ShellFunc[[]int], cmp.Compare[int])
+}
+
+func TestShellFunc_Data(t *testing.T) {
+	testSortFuncData(t, sort.ShellFunc[[]int], cmp.Compare[int], shellFuncData)
+}
+
+func TestShellFunc_Reverse(t *testing.T) {
+	testSortFuncReverse(t, sort.ShellFunc[[]int], cmp.Compare[int], shellFuncData)
+}
+
+func TestShellFunc_RandomInts(t *testing.T) {
+	testSortFuncRandomInts(t, sort.ShellFunc[[]int], cmp.Compare[int])
+}
+
+func TestShellGapsFunc_Patterns(t *testing.T) {
+	for name, gaps := range shellGaps {
+		t.Run(name, func(t *testing.T) {
+			testSortFuncPatterns(t, shellGapsFunc(gaps), cmp.Compare[int])
+		})
+	}
+}
+
+func TestShellGaps(t *testing.T) {
+	tests := map[string]struct {
+		gaps []int
+		want []int
+	}{
+		"Knuth":     {sort.KnuthGaps(5000), []int{1, 4, 13, 40, 121, 364, 1093, 3280}},
+		"Sedgewick": {sort.SedgewickGaps(20000), []int{1, 8, 23, 77, 281, 1073, 4193, 16577}},
+		"Tokuda":    {sort.TokudaGaps(6000), []int{1, 4, 9, 20, 46, 103, 233, 525, 1182, 2660, 5985}},
+		"Ciura":     {sort.CiuraGaps(4000), []int{1, 4, 10, 23, 57, 132, 301, 701, 1750, 3937}},
+		"bound":     {sort.KnuthGaps(40), []int{1, 4, 13}},
+		"one":       {sort.CiuraGaps(1), []int{1}},
+		"empty":     {sort.TokudaGaps(0), []int{1}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if !slices.Equal(tt.gaps, tt.want) {
+				t.Errorf("got %v; want %v", tt.gaps, tt.want)
+			}
+		})
+	}
+
+	// ShellGapsFunc panics if the gaps up to the largest length are not strictly increasing, for example because of an overflow.
+	for name, gaps := range shellGaps {
+		t.Run(name+"/max", func(t *testing.T) {
+			sort.ShellGapsFunc([]int{2, 1}, gaps(math.MaxInt), cmp.Compare[int])
+		})
+	}
+}
+
+func TestShellGapsFunc_Panic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("got no panic; want panic")
+		}
+	}()
+
+	sort.ShellGapsFunc([]int{2, 1}, []int{2, 5}, cmp.Compare[int])
+}
+
+func TestShellGapsFunc_PanicUnsorted(t *testing.T) {
+	for _, gaps := range [][]int{{1, 50, 4}, {1, 1}} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("gaps %v: got no panic; want panic", gaps)
+				}
+			}()
+
+			sort.ShellGapsFunc([]int{2, 1}, gaps, cmp.Compare[int])
+		}()
+	}
+}
+
+func TestShellGaps_Copy(t *testing.T) {
+	gaps := sort.CiuraGaps(100)
+	gaps[1] = 100
+	if got := sort.CiuraGaps(100)[1]; got != 4 {
+		t.Errorf("got modified gap %d; want 4", got)
+	}
+}
+
+func BenchmarkShellFunc1K(b *testing.B) {
+	benchmarkSortFunc1K(b, sort.ShellFunc[[]int], cmp.Compare[int])
+}
+
+func BenchmarkShellGapsFunc64K(b *testing.B) {
+	for _, name := range []string{"Knuth", "Sedgewick", "Tokuda", "Ciura"} {
+		b.Run(name, func(b *testing.B) {
+			benchmarkSortFuncPatterns(b, 1<<16, shellGapsFunc(shellGaps[name]), cmp.Compare[int])
+		})
+	}
+}
+
+func FuzzShellFunc(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s []byte) {
+		sort.ShellFunc(s, cmp.Compare)
+
+		if !slices.IsSortedFunc(s, cmp.Compare) {
+			t.Errorf("slice was not sorted")
+		}
+	})
+}
@@ -3,11 +3,10 @@
 package sort_test
 
 import (
+	"cmp"
 	"math/rand"
 	"slices"
 	"testing"
-
-	"github.com/denpeshkov/algorithms/sort"
 )
 
 func testSortFuncEmptyNil[S ~[]E, E any](t *testing.T, sortFunc func(S, func(E, E) int), cmp func(E, E) int) {
@@ -76,7 +75,7 @@ func testSortFuncRandomInts(t *testing.T, sortFunc func([]int, func(int, int) in
 		t.Fatal("terrible rand")
 	}
 
-	sort.InsertionFunc(data, cmp)
+	sortFunc(data, cmp)
 
 	if !slices.IsSorted(data) {
 		t.Errorf("sort didn't sort - %d random ints", n)
@@ -163,6 +162,50 @@ func testSortFuncStability(t *testing.T, sortFunc func(intPairs, func(intPair, i
 	}
 }
 
+// sortPatterns returns generators of the i-th element of various input patterns of length n.
+func sortPatterns(n int) map[string]func(i int) int {
+	return map[string]func(i int) int{
+		"random":     func(i int) int { return rand.Intn(n) },
+		"sorted":     func(i int) int { return i },
+		"reversed":   func(i int) int { return n - i },
+		"equal":      func(i int) int { return 0 },
+		"few unique": func(i int) int { return rand.Intn(4) },
+		"sawtooth":   func(i int) int { return i % 64 },
+		"organ pipe": func(i int) int { return min(i, n-i) },
+		"interleave": func(i int) int { return i%2*n + i },
+		"almost sorted": func(i int) int {
+			if i%100 == 0 {
+				return rand.Intn(n)
+			}
+			return i
+		},
+	}
+}
+
+func testSortFuncPatterns(t *testing.T, sortFunc func([]int, func(int, int) int), cmp func(int, int) int) {
+	t.Parallel()
+
+	n := 10000
+	if testing.Short() {
+		n = 1000
+	}
+
+	for name, p := range sortPatterns(n) {
+		t.Run(name, func(t *testing.T) {
+			data := make([]int, n)
+			for i := range data {
+				data[i] = p(i)
+			}
+
+			sortFunc(data, cmp)
+
+			if !slices.IsSortedFunc(data, cmp) {
+				t.Errorf("sort didn't sort %d ints of pattern %q", n, name)
+			}
+		})
+	}
+}
+
 func benchmarkSortFunc1K(b *testing.B, sortFunc func([]int, func(int, int) int), cmp func(int, int) int) {
 	b.StopTimer()
 	for i := 0; i < b.N; i++ {
@@ -176,6 +219,68 @@ func benchmarkSortFunc1K(b *testing.B, sortFunc func([]int, func(int, int) int),
 	}
 }
 
+func benchmarkSortFuncRandom(b *testing.B, n int, sortFunc func([]int, func(int, int) int), cmp func(int, int) int) {
+	b.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		data := make([]int, n)
+		for i := 0; i < len(data); i++ {
+			data[i] = rnd.Int()
+		}
+		b.StartTimer()
+		sortFunc(data, cmp)
+		b.StopTimer()
+	}
+}
+
+// benchmarkSortFuncAppended benchmarks sorting of a sorted slice of length n with a few random elements appended to it.
+func benchmarkSortFuncAppended(b *testing.B, n int, sortFunc func([]int, func(int, int) int), cmp func(int, int) int) {
+	b.StopTimer()
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		data := make([]int, n)
+		m := n - n/16
+		for i := 0; i < m; i++ {
+			data[i] = i
+		}
+		for i := m; i < n; i++ {
+			data[i] = rnd.Intn(n)
+		}
+		b.StartTimer()
+		sortFunc(data, cmp)
+		b.StopTimer()
+	}
+}
+
+// benchmarkSortFuncPatterns benchmarks sorting of slices of length n of the random, sorted and reversed patterns.
+func benchmarkSortFuncPatterns(b *testing.B, n int, sortFunc func([]int, func(int, int) int), cmp func(int, int) int) {
+	patterns := sortPatterns(n)
+
+	for _, name := range []string{"random", "sorted", "reversed"} {
+		p := patterns[name]
+		b.Run(name, func(b *testing.B) {
+			b.StopTimer()
+			for i := 0; i < b.N; i++ {
+				data := make([]int, n)
+				for i := range data {
+					data[i] = p(i)
+				}
+				b.StartTimer()
+				sortFunc(data, cmp)
+				b.StopTimer()
+			}
+		})
+	}
+}
+
+func BenchmarkSlicesSortFunc1K(b *testing.B) {
+	benchmarkSortFunc1K(b, slices.SortFunc[[]int], cmp.Compare[int])
+}
+
+func BenchmarkSlicesSortFuncRandom1M(b *testing.B) {
+	benchmarkSortFuncRandom(b, 1<<20, slices.SortFunc[[]int], cmp.Compare[int])
+}
+
 func reverse[T any](fn func(x, y T) int) func(x, y T) int {
 	return func(x, y T) int { return fn(y, x) }
 }
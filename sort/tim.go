@@ -0,0 +1,518 @@
+// Based on https://github.com/openjdk/jdk/blob/master/src/java.base/share/classes/java/util/TimSort.java
+
+package sort
+
+const (
+	// timMinMerge is the length of a slice below which no merging is done and binary insertion sort is used instead.
+	timMinMerge = 32
+	// timMinGallop is the initial number of consecutive wins of one run after which the merge enters galloping mode.
+	timMinGallop = 7
+)
+
+// TimFunc implements Timsort using a custom comparison function.
+// It detects natural ascending and descending runs in the slice, extends short runs using binary insertion sort
+// and merges them using galloping mode.
+// It runs in O(n) time for already sorted slices and O(n*log(n)) time in the worst case,
+// and requires at most n/2 elements of auxiliary space.
+// The sort is stable.
+func TimFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	timSortFunc(s, cmp, nil)
+}
+
+func timSortFunc[E any](s []E, cmp func(a, b E) int, p *probe) {
+	n := len(s)
+	if n < 2 {
+		return
+	}
+
+	if n < timMinMerge {
+		r := timCountRun(s, cmp, p)
+		binaryInsertion(s, r, cmp, p)
+		return
+	}
+
+	ts := &timSort[E]{s: s, cmp: cmp, minGallop: timMinGallop, p: p}
+	minRun := timMinRun(n)
+
+	for lo := 0; lo < n; {
+		r := timCountRun(s[lo:], cmp, p)
+
+		// Extend a short run to min(minRun, n-lo) elements.
+		if r < minRun {
+			force := min(minRun, n-lo)
+			binaryInsertion(s[lo:lo+force], r, cmp, p)
+			r = force
+		}
+
+		ts.runs = append(ts.runs, timRun{lo, r})
+		ts.mergeCollapse()
+
+		lo += r
+	}
+
+	ts.mergeForceCollapse()
+}
+
+// timRun is a sorted run s[base:base+len].
+type timRun struct {
+	base, len int
+}
+
+// timSort holds the state of a single Timsort invocation.
+type timSort[E any] struct {
+	s         []E
+	cmp       func(a, b E) int
+	minGallop int
+	tmp       []E      // merge scratch space
+	runs      []timRun // stack of pending runs
+	p         *probe
+}
+
+// mergeCollapse merges the runs on the stack until the invariants are established:
+//   - runs[i-2].len > runs[i-1].len + runs[i].len;
+//   - runs[i-1].len > runs[i].len.
+//
+// See http://envisage-project.eu/wp-content/uploads/2015/02/sorting.pdf for why the invariants are checked for the top 4 runs.
+func (ts *timSort[E]) mergeCollapse() {
+	for len(ts.runs) > 1 {
+		runs := ts.runs
+		n := len(runs) - 2
+
+		if n > 0 && runs[n-1].len <= runs[n].len+runs[n+1].len || n > 1 && runs[n-2].len <= runs[n-1].len+runs[n].len {
+			if runs[n-1].len < runs[n+1].len {
+				n--
+			}
+		} else if runs[n].len > runs[n+1].len {
+			return
+		}
+
+		ts.mergeAt(n)
+	}
+}
+
+// mergeForceCollapse merges all the runs on the stack until only one remains.
+func (ts *timSort[E]) mergeForceCollapse() {
+	for len(ts.runs) > 1 {
+		n := len(ts.runs) - 2
+		if n > 0 && ts.runs[n-1].len < ts.runs[n+1].len {
+			n--
+		}
+
+		ts.mergeAt(n)
+	}
+}
+
+// mergeAt merges the two runs at stack indexes i and i+1.
+func (ts *timSort[E]) mergeAt(i int) {
+	s, cmp := ts.s, ts.cmp
+	r1, r2 := ts.runs[i], ts.runs[i+1]
+
+	ts.runs[i].len = r1.len + r2.len
+	if i == len(ts.runs)-3 {
+		ts.runs[i+1] = ts.runs[i+2]
+	}
+	ts.runs = ts.runs[:len(ts.runs)-1]
+
+	// Elements of r1 that are <= the first element of r2 are already in place.
+	k := gallopRight(s[r2.base], s[r1.base:r1.base+r1.len], 0, cmp)
+	r1.base += k
+	r1.len -= k
+	if r1.len == 0 {
+		return
+	}
+
+	// Elements of r2 that are >= the last element of r1 are already in place.
+	r2.len = gallopLeft(s[r1.base+r1.len-1], s[r2.base:r2.base+r2.len], r2.len-1, cmp)
+	if r2.len == 0 {
+		return
+	}
+
+	// Every element of both runs is written once, and the shorter run is also copied to the scratch space.
+	ts.p.move(min(r1.len, r2.len) + r1.len + r2.len)
+
+	if r1.len <= r2.len {
+		ts.mergeLo(r1, r2)
+	} else {
+		ts.mergeHi(r1, r2)
+	}
+}
+
+// mergeLo merges adjacent runs r1 and r2 left to right, using r1.len elements of scratch space.
+// It must be that r1.len <= r2.len, the first element of r1 is greater than the first element of r2
+// and the last element of r1 is greater than all elements of r2.
+func (ts *timSort[E]) mergeLo(r1, r2 timRun) {
+	s, cmp := ts.s, ts.cmp
+	len1, len2 := r1.len, r2.len
+
+	tmp := ts.scratch(len1)
+	copy(tmp, s[r1.base:r1.base+len1])
+
+	c1, c2, dst := 0, r2.base, r1.base // cursors into tmp, r2 and the destination
+
+	s[dst] = s[c2]
+	dst++
+	c2++
+	len2--
+	if len2 == 0 {
+		copy(s[dst:], tmp[c1:c1+len1])
+		return
+	}
+	if len1 == 1 {
+		copy(s[dst:], s[c2:c2+len2])
+		s[dst+len2] = tmp[c1]
+		return
+	}
+
+	minGallop := ts.minGallop
+outer:
+	for {
+		count1, count2 := 0, 0 // number of consecutive wins of each run
+
+		// One-at-a-time mode, until one run starts winning consistently.
+		for {
+			if cmp(s[c2], tmp[c1]) < 0 {
+				s[dst] = s[c2]
+				dst++
+				c2++
+				count2++
+				count1 = 0
+				len2--
+				if len2 == 0 {
+					break outer
+				}
+			} else {
+				s[dst] = tmp[c1]
+				dst++
+				c1++
+				count1++
+				count2 = 0
+				len1--
+				if len1 == 1 {
+					break outer
+				}
+			}
+			if count1|count2 >= minGallop {
+				break
+			}
+		}
+
+		// Galloping mode, until neither run is winning consistently.
+		for {
+			count1 = gallopRight(s[c2], tmp[c1:c1+len1], 0, cmp)
+			if count1 != 0 {
+				copy(s[dst:], tmp[c1:c1+count1])
+				dst += count1
+				c1 += count1
+				len1 -= count1
+				if len1 <= 1 {
+					break outer
+				}
+			}
+			s[dst] = s[c2]
+			dst++
+			c2++
+			len2--
+			if len2 == 0 {
+				break outer
+			}
+
+			count2 = gallopLeft(tmp[c1], s[c2:c2+len2], 0, cmp)
+			if count2 != 0 {
+				copy(s[dst:], s[c2:c2+count2])
+				dst += count2
+				c2 += count2
+				len2 -= count2
+				if len2 == 0 {
+					break outer
+				}
+			}
+			s[dst] = tmp[c1]
+			dst++
+			c1++
+			len1--
+			if len1 == 1 {
+				break outer
+			}
+
+			minGallop--
+			if count1 < timMinGallop && count2 < timMinGallop {
+				break
+			}
+		}
+
+		// Penalize leaving galloping mode.
+		minGallop = max(minGallop, 0) + 2
+	}
+	ts.minGallop = max(minGallop, 1)
+
+	if len1 == 1 {
+		copy(s[dst:], s[c2:c2+len2])
+		s[dst+len2] = tmp[c1]
+	} else {
+		copy(s[dst:], tmp[c1:c1+len1])
+	}
+}
+
+// mergeHi merges adjacent runs r1 and r2 right to left, using r2.len elements of scratch space.
+// It must be that r1.len >= r2.len, the first element of r1 is greater than the first element of r2
+// and the last element of r1 is greater than all elements of r2.
+func (ts *timSort[E]) mergeHi(r1, r2 timRun) {
+	s, cmp := ts.s, ts.cmp
+	len1, len2 := r1.len, r2.len
+
+	tmp := ts.scratch(len2)
+	copy(tmp, s[r2.base:r2.base+len2])
+
+	c1, c2, dst := r1.base+len1-1, len2-1, r2.base+len2-1 // cursors into r1, tmp and the destination
+
+	s[dst] = s[c1]
+	dst--
+	c1--
+	len1--
+	if len1 == 0 {
+		copy(s[dst-(len2-1):], tmp[:len2])
+		return
+	}
+	if len2 == 1 {
+		dst -= len1
+		c1 -= len1
+		copy(s[dst+1:], s[c1+1:c1+1+len1])
+		s[dst] = tmp[c2]
+		return
+	}
+
+	minGallop := ts.minGallop
+outer:
+	for {
+		count1, count2 := 0, 0 // number of consecutive wins of each run
+
+		// One-at-a-time mode, until one run starts winning consistently.
+		for {
+			if cmp(tmp[c2], s[c1]) < 0 {
+				s[dst] = s[c1]
+				dst--
+				c1--
+				count1++
+				count2 = 0
+				len1--
+				if len1 == 0 {
+					break outer
+				}
+			} else {
+				s[dst] = tmp[c2]
+				dst--
+				c2--
+				count2++
+				count1 = 0
+				len2--
+				if len2 == 1 {
+					break outer
+				}
+			}
+			if count1|count2 >= minGallop {
+				break
+			}
+		}
+
+		// Galloping mode, until neither run is winning consistently.
+		for {
+			count1 = len1 - gallopRight(tmp[c2], s[r1.base:r1.base+len1], len1-1, cmp)
+			if count1 != 0 {
+				dst -= count1
+				c1 -= count1
+				len1 -= count1
+				copy(s[dst+1:], s[c1+1:c1+1+count1])
+				if len1 == 0 {
+					break outer
+				}
+			}
+			s[dst] = tmp[c2]
+			dst--
+			c2--
+			len2--
+			if len2 == 1 {
+				break outer
+			}
+
+			count2 = len2 - gallopLeft(s[c1], tmp[:len2], len2-1, cmp)
+			if count2 != 0 {
+				dst -= count2
+				c2 -= count2
+				len2 -= count2
+				copy(s[dst+1:], tmp[c2+1:c2+1+count2])
+				if len2 <= 1 {
+					break outer
+				}
+			}
+			s[dst] = s[c1]
+			dst--
+			c1--
+			len1--
+			if len1 == 0 {
+				break outer
+			}
+
+			minGallop--
+			if count1 < timMinGallop && count2 < timMinGallop {
+				break
+			}
+		}
+
+		// Penalize leaving galloping mode.
+		minGallop = max(minGallop, 0) + 2
+	}
+	ts.minGallop = max(minGallop, 1)
+
+	if len2 == 1 {
+		dst -= len1
+		c1 -= len1
+		copy(s[dst+1:], s[c1+1:c1+1+len1])
+		s[dst] = tmp[c2]
+	} else {
+		copy(s[dst-(len2-1):], tmp[:len2])
+	}
+}
+
+// scratch returns a scratch slice of length n, reusing the previously allocated one if possible.
+func (ts *timSort[E]) scratch(n int) []E {
+	if cap(ts.tmp) < n {
+		ts.p.alloc(n)
+		ts.tmp = make([]E, n)
+	}
+	return ts.tmp[:n]
+}
+
+// gallopLeft returns the leftmost index at which key can be inserted into the sorted slice s,
+// i.e. index k such that s[k-1] < key <= s[k].
+// The search starts at index hint, so it's fast if the result is close to hint.
+func gallopLeft[E any](key E, s []E, hint int, cmp func(a, b E) int) int {
+	lo, hi := 0, 1 // offsets from hint
+
+	if cmp(key, s[hint]) > 0 {
+		// Gallop right until s[hint+lo] < key <= s[hint+hi].
+		maxOfs := len(s) - hint
+		for hi < maxOfs && cmp(key, s[hint+hi]) > 0 {
+			lo = hi
+			hi = hi<<1 + 1
+		}
+		hi = min(hi, maxOfs)
+		lo, hi = hint+lo, hint+hi
+	} else {
+		// Gallop left until s[hint-hi] < key <= s[hint-lo].
+		maxOfs := hint + 1
+		for hi < maxOfs && cmp(key, s[hint-hi]) <= 0 {
+			lo = hi
+			hi = hi<<1 + 1
+		}
+		hi = min(hi, maxOfs)
+		lo, hi = hint-hi, hint-lo
+	}
+
+	// Now s[lo] < key <= s[hi], binary search in between.
+	lo++
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		if cmp(key, s[m]) > 0 {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+	return hi
+}
+
+// gallopRight returns the rightmost index at which key can be inserted into the sorted slice s,
+// i.e. index k such that s[k-1] <= key < s[k].
+// The search starts at index hint, so it's fast if the result is close to hint.
+func gallopRight[E any](key E, s []E, hint int, cmp func(a, b E) int) int {
+	lo, hi := 0, 1 // offsets from hint
+
+	if cmp(key, s[hint]) < 0 {
+		// Gallop left until s[hint-hi] <= key < s[hint-lo].
+		maxOfs := hint + 1
+		for hi < maxOfs && cmp(key, s[hint-hi]) < 0 {
+			lo = hi
+			hi = hi<<1 + 1
+		}
+		hi = min(hi, maxOfs)
+		lo, hi = hint-hi, hint-lo
+	} else {
+		// Gallop right until s[hint+lo] <= key < s[hint+hi].
+		maxOfs := len(s) - hint
+		for hi < maxOfs && cmp(key, s[hint+hi]) >= 0 {
+			lo = hi
+			hi = hi<<1 + 1
+		}
+		hi = min(hi, maxOfs)
+		lo, hi = hint+lo, hint+hi
+	}
+
+	// Now s[lo] <= key < s[hi], binary search in between.
+	lo++
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		if cmp(key, s[m]) < 0 {
+			hi = m
+		} else {
+			lo = m + 1
+		}
+	}
+	return hi
+}
+
+// timCountRun returns the length of the run at the beginning of the slice.
+// A strictly descending run is reversed in place, so that the run is always ascending.
+func timCountRun[E any](s []E, cmp func(a, b E) int, p *probe) int {
+	n := len(s)
+	if n < 2 {
+		return n
+	}
+
+	i := 2
+	if cmp(s[1], s[0]) < 0 {
+		// Descending runs must be strict to preserve stability when reversed.
+		for i < n && cmp(s[i], s[i-1]) < 0 {
+			i++
+		}
+		reverse(s[:i], p)
+	} else {
+		for i < n && cmp(s[i], s[i-1]) >= 0 {
+			i++
+		}
+	}
+	return i
+}
+
+// timMinRun returns the minimum run length for a slice of length n.
+// Short runs are extended to this length, so that the number of runs is a power of 2 or slightly less.
+func timMinRun(n int) int {
+	r := 0
+	for n >= timMinMerge {
+		r |= n & 1
+		n >>= 1
+	}
+	return n + r
+}
+
+// binaryInsertion sorts the slice s using binary insertion sort, given that s[:start] is already sorted.
+// The sort is stable.
+func binaryInsertion[E any](s []E, start int, cmp func(a, b E) int, p *probe) {
+	for i := max(start, 1); i < len(s); i++ {
+		v := s[i]
+
+		// Find the rightmost position to insert v into s[:i] to preserve stability.
+		lo, hi := 0, i
+		for lo < hi {
+			m := int(uint(lo+hi) >> 1)
+			if cmp(v, s[m]) < 0 {
+				hi = m
+			} else {
+				lo = m + 1
+			}
+		}
+
+		copy(s[lo+1:i+1], s[lo:i])
+		s[lo] = v
+		p.move(i - lo + 1)
+	}
+}
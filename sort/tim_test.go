@@ -0,0 +1,62 @@
+package sort_test
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+var timFuncData = []int{74, 59, 238, -784, 9845, 959, 905, 0, 0, 42, 7586, -5467984, 7586}
+
+func TestTimFunc_EmptyNil(t *testing.T) {
+	testSortFuncEmptyNil(t, sort.TimFunc[[]int], cmp.Compare[int])
+}
+
+func TestTimFunc_Data(t *testing.T) {
+	testSortFuncData(t, sort.TimFunc[[]int], cmp.Compare[int], timFuncData)
+}
+
+func TestTimFunc_Reverse(t *testing.T) {
+	testSortFuncReverse(t, sort.TimFunc[[]int], cmp.Compare[int], timFuncData)
+}
+
+func TestTimFunc_RandomInts(t *testing.T) {
+	testSortFuncRandomInts(t, sort.TimFunc[[]int], cmp.Compare[int])
+}
+
+func TestTimFunc_Patterns(t *testing.T) {
+	testSortFuncPatterns(t, sort.TimFunc[[]int], cmp.Compare[int])
+}
+
+func TestTimFunc_Stability(t *testing.T) {
+	n, m := 100000, 1000
+	if testing.Short() {
+		n, m = 1000, 100
+	}
+
+	testSortFuncStability(t, sort.TimFunc[intPairs], n, m)
+}
+
+func BenchmarkTimFunc1K(b *testing.B) {
+	benchmarkSortFunc1K(b, sort.TimFunc[[]int], cmp.Compare[int])
+}
+
+func BenchmarkTimFuncRandom1M(b *testing.B) {
+	benchmarkSortFuncRandom(b, 1<<20, sort.TimFunc[[]int], cmp.Compare[int])
+}
+
+func BenchmarkTimFuncAppended1M(b *testing.B) {
+	benchmarkSortFuncAppended(b, 1<<20, sort.TimFunc[[]int], cmp.Compare[int])
+}
+
+func FuzzTimFunc(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s []byte) {
+		sort.TimFunc(s, cmp.Compare)
+
+		if !slices.IsSortedFunc(s, cmp.Compare) {
+			t.Errorf("slice was not sorted")
+		}
+	})
+}
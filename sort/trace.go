@@ -0,0 +1,85 @@
+package sort
+
+import "fmt"
+
+// Op is the kind of an operation performed by a sorting algorithm on the slice.
+type Op int
+
+const (
+	OpCompare Op = iota // the elements at indexes I and J are compared
+	OpSwap              // the elements at indexes I and J are swapped
+	OpWrite             // Value is written to index I
+)
+
+var opNames = [...]string{
+	OpCompare: "Compare",
+	OpSwap:    "Swap",
+	OpWrite:   "Write",
+}
+
+// String returns the name of the operation.
+func (op Op) String() string {
+	if op < 0 || int(op) >= len(opNames) {
+		return fmt.Sprintf("Op(%d)", int(op))
+	}
+	return opNames[op]
+}
+
+// Event is an operation performed by a sorting algorithm on the slice.
+type Event[E any] struct {
+	Op    Op
+	I, J  int // J is -1 for writes
+	Value E   // the written element for writes
+}
+
+// Trace sorts the slice using the algorithm and a custom comparison function,
+// calling hook for every comparison, swap and write of the elements of the slice in the order they are performed.
+// Comparisons are reported right before cmp is called, swaps and writes right after they are performed.
+//
+// Writes to auxiliary buffers are not reported.
+// A comparison with an element held outside the slice, such as the element being inserted by insertion sort,
+// is reported with the index of the hole the element will be written to.
+//
+// It panics if the algorithm can't be traced.
+func Trace[S ~[]E, E any](alg Algorithm, s S, cmp func(a, b E) int, hook func(e Event[E])) {
+	if !alg.Traceable() {
+		panic(fmt.Sprintf("sort: algorithm %v can't be traced", alg))
+	}
+
+	p := &probe{
+		stats: &Stats{},
+		trace: func(op Op, i, j int) {
+			e := Event[E]{Op: op, I: i, J: j}
+			if op == OpWrite {
+				e.Value = s[i]
+			}
+			hook(e)
+		},
+	}
+
+	sortProbe(alg, s, cmp, p)
+}
+
+// Traceable reports whether the algorithm can be traced using [Trace].
+// Only [InsertionSort], [SelectionSort], [MergeSort], [MergeBottomUpSort], [HeapSort] and [ShellSort] can be traced.
+func (a Algorithm) Traceable() bool {
+	switch a {
+	case InsertionSort, SelectionSort, MergeSort, MergeBottomUpSort, HeapSort, ShellSort:
+		return true
+	default:
+		return false
+	}
+}
+
+// Replay applies the events to the slice, reproducing the operations of the traced sort.
+// Comparisons don't change the slice.
+func Replay[S ~[]E, E any](s S, events []Event[E]) {
+	for _, e := range events {
+		switch e.Op {
+		case OpSwap:
+			s[e.I], s[e.J] = s[e.J], s[e.I]
+		case OpWrite:
+			s[e.I] = e.Value
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package sort_test
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/denpeshkov/algorithms/sort"
+)
+
+var traceAlgorithms = []sort.Algorithm{
+	sort.InsertionSort,
+	sort.SelectionSort,
+	sort.MergeSort,
+	sort.MergeBottomUpSort,
+	sort.HeapSort,
+	sort.ShellSort,
+}
+
+func TestTrace(t *testing.T) {
+	n := 300
+	for _, alg := range traceAlgorithms {
+		for name, p := range sortPatterns(n) {
+			t.Run(alg.String()+"/"+name, func(t *testing.T) {
+				data := make([]int, n)
+				for i := range data {
+					data[i] = p(i)
+				}
+				orig := slices.Clone(data)
+
+				var events []sort.Event[int]
+				sort.Trace(alg, data, cmp.Compare[int], func(e sort.Event[int]) {
+					events = append(events, e)
+				})
+
+				if !slices.IsSorted(data) {
+					t.Fatalf("%v didn't sort %d ints of pattern %q", alg, n, name)
+				}
+
+				var compares int64
+				for _, e := range events {
+					if e.I < 0 || e.I >= n || e.Op != sort.OpWrite && (e.J < 0 || e.J >= n) {
+						t.Fatalf("event %+v out of range", e)
+					}
+					if e.Op == sort.OpCompare {
+						compares++
+					}
+				}
+				if st := sort.Measure(alg, slices.Clone(orig), cmp.Compare[int]); compares != st.Comparisons {
+					t.Errorf("traced %d comparisons; want %d", compares, st.Comparisons)
+				}
+
+				sort.Replay(orig, events)
+				if !slices.Equal(orig, data) {
+					t.Errorf("Replay = %v; want %v", orig, data)
+				}
+			})
+		}
+	}
+}
+
+func TestTrace_Compare(t *testing.T) {
+	// Every comparison must be traced right before it's performed at indexes consistent with the replayed state of the slice.
+	data := []int{5, 1, 4, 2, 3, 9, 0, 7, 8, 6}
+	for _, alg := range []sort.Algorithm{sort.SelectionSort, sort.HeapSort, sort.MergeSort, sort.MergeBottomUpSort} {
+		t.Run(alg.String(), func(t *testing.T) {
+			s := slices.Clone(data)
+			state := slices.Clone(data)
+			var last sort.Event[int]
+			sort.Trace(alg, s, func(a, b int) int {
+				if last.Op != sort.OpCompare {
+					t.Fatalf("comparison of %d and %d wasn't traced", a, b)
+				}
+				if x, y := state[last.I], state[last.J]; x != a || y != b {
+					t.Fatalf("traced comparison of %d and %d at (%d, %d); want %d and %d", x, y, last.I, last.J, a, b)
+				}
+				last.Op = -1
+				return cmp.Compare(a, b)
+			}, func(e sort.Event[int]) {
+				last = e
+				sort.Replay(state, []sort.Event[int]{e})
+			})
+		})
+	}
+}
+
+func TestAlgorithm_Traceable(t *testing.T) {
+	for _, alg := range sort.Algorithms {
+		if got, want := alg.Traceable(), slices.Contains(traceAlgorithms, alg); got != want {
+			t.Errorf("%v.Traceable() = %t; want %t", alg, got, want)
+		}
+	}
+}
+
+func TestTrace_Unsupported(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Trace didn't panic on an untraceable algorithm")
+		}
+	}()
+	sort.Trace(sort.PdqSort, []int{2, 1}, cmp.Compare[int], func(sort.Event[int]) {})
+}
+
+func TestOp_String(t *testing.T) {
+	if s := sort.OpSwap.String(); s != "Swap" {
+		t.Errorf("OpSwap.String() = %q; want %q", s, "Swap")
+	}
+	if s := sort.Op(7).String(); s != "Op(7)" {
+		t.Errorf("Op(7).String() = %q; want %q", s, "Op(7)")
+	}
+}